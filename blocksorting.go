@@ -1,7 +1,12 @@
 package navigadoc
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/navigacontentlab/navigadoc/doc"
 )
@@ -9,6 +14,9 @@ import (
 type BlockSorter struct {
 	Blocks         []doc.Block
 	BlockSortOrder map[string]int
+	// Unknown controls where types missing from BlockSortOrder are
+	// placed, the empty value sorts them as if they had position 0.
+	Unknown UnknownPlacement
 }
 
 func NewBlockSorter(blockSortOrder map[string]int) BlockSorter {
@@ -20,11 +28,24 @@ func (b BlockSorter) Len() int {
 }
 
 func (b BlockSorter) Less(i, j int) bool {
-	if b.BlockSortOrder[b.Blocks[i].Type] == b.BlockSortOrder[b.Blocks[j].Type] {
-		return false
+	return b.position(b.Blocks[i].Type) < b.position(b.Blocks[j].Type)
+}
+
+func (b BlockSorter) position(blockType string) int {
+	pos, ok := b.BlockSortOrder[blockType]
+	if ok {
+		return pos
 	}
 
-	return b.BlockSortOrder[b.Blocks[i].Type] < b.BlockSortOrder[b.Blocks[j].Type]
+	switch b.Unknown {
+	case UnknownFirst:
+		return math.MinInt32
+	case UnknownLast:
+		return math.MaxInt32
+	case UnknownZero, "":
+	}
+
+	return 0
 }
 
 func (b BlockSorter) Swap(i, j int) { b.Blocks[i], b.Blocks[j] = b.Blocks[j], b.Blocks[i] }
@@ -34,3 +55,332 @@ func (b *BlockSorter) SortBlocks(blocks []doc.Block) []doc.Block {
 	sort.Stable(b)
 	return b.Blocks
 }
+
+// UnknownPlacement controls where blocks are placed when their sort
+// value is missing, can't be parsed or isn't part of an explicit order.
+type UnknownPlacement string
+
+const (
+	UnknownLast  UnknownPlacement = "last"
+	UnknownFirst UnknownPlacement = "first"
+	// UnknownZero gives unknown values position 0 in an explicit order,
+	// and is treated like UnknownLast otherwise.
+	UnknownZero UnknownPlacement = "zero"
+)
+
+// SortValueKind tells how a sort value should be compared.
+type SortValueKind string
+
+const (
+	SortAsString SortValueKind = "string"
+	SortAsNumber SortValueKind = "number"
+	// SortAsTime compares RFC3339 timestamps.
+	SortAsTime SortValueKind = "time"
+)
+
+// SortKey is a single sort criterion.
+type SortKey struct {
+	// Field is one of "type", "rel", "title", "name", "value", "id",
+	// "uuid", "uri", "url", "role", "contentType" or "data.<key>".
+	Field string `json:"field"`
+	// As is the kind of comparison, it defaults to SortAsString.
+	As SortValueKind `json:"as,omitempty"`
+	// Order is an explicit order of values, values not in the list are
+	// placed according to Unknown.
+	Order []string `json:"order,omitempty"`
+	// Descending reverses the order of known values.
+	Descending bool `json:"descending,omitempty"`
+	// Unknown defaults to UnknownLast.
+	Unknown UnknownPlacement `json:"unknown,omitempty"`
+}
+
+// SortRule applies sort keys to the block lists that it matches.
+type SortRule struct {
+	// Section limits the rule to content, meta or links, the empty
+	// value matches all sections.
+	Section Section `json:"section,omitempty"`
+	// Parent limits the rule to lists whose parent block has this type,
+	// or to the lists directly on documents of this type. The empty
+	// value matches all parents.
+	Parent string    `json:"parent,omitempty"`
+	Keys   []SortKey `json:"keys"`
+}
+
+// SortConfig is a set of sort rules. The first matching rule is used
+// for each list of blocks.
+type SortConfig struct {
+	Rules []SortRule `json:"rules"`
+	// Recursive applies the rules to the nested lists of blocks as
+	// well.
+	Recursive bool `json:"recursive,omitempty"`
+}
+
+// LoadSortConfig parses and checks a JSON sort configuration.
+func LoadSortConfig(data []byte) (*SortConfig, error) {
+	var config SortConfig
+
+	err := json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sort configuration: %w", err)
+	}
+
+	err = config.Check()
+	if err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+var sortFields = map[string]func(b *doc.Block) string{
+	"type":        func(b *doc.Block) string { return b.Type },
+	"rel":         func(b *doc.Block) string { return b.Rel },
+	"title":       func(b *doc.Block) string { return b.Title },
+	"name":        func(b *doc.Block) string { return b.Name },
+	"value":       func(b *doc.Block) string { return b.Value },
+	"id":          func(b *doc.Block) string { return b.ID },
+	"uuid":        func(b *doc.Block) string { return b.UUID },
+	"uri":         func(b *doc.Block) string { return b.URI },
+	"url":         func(b *doc.Block) string { return b.URL },
+	"role":        func(b *doc.Block) string { return b.Role },
+	"contentType": func(b *doc.Block) string { return b.ContentType },
+}
+
+// Check verifies that the rules only use known fields, kinds, sections
+// and placements.
+func (c SortConfig) Check() error {
+	for i, rule := range c.Rules {
+		switch rule.Section {
+		case "", SectionContent, SectionMeta, SectionLinks:
+		default:
+			return fmt.Errorf("sort rule %d: unknown section %q", i, rule.Section)
+		}
+
+		if len(rule.Keys) == 0 {
+			return fmt.Errorf("sort rule %d: no sort keys", i)
+		}
+
+		for _, key := range rule.Keys {
+			_, known := sortFields[key.Field]
+			if !known && !strings.HasPrefix(key.Field, "data.") {
+				return fmt.Errorf("sort rule %d: unknown field %q", i, key.Field)
+			}
+
+			switch key.As {
+			case "", SortAsString, SortAsNumber, SortAsTime:
+			default:
+				return fmt.Errorf("sort rule %d: unknown kind %q for %q", i, key.As, key.Field)
+			}
+
+			switch key.Unknown {
+			case "", UnknownFirst, UnknownLast, UnknownZero:
+			default:
+				return fmt.Errorf("sort rule %d: unknown placement %q for %q", i, key.Unknown, key.Field)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SortBlocks stably sorts the blocks in place using the keys.
+func SortBlocks(blocks []doc.Block, keys ...SortKey) []doc.Block {
+	if len(keys) == 0 || len(blocks) < 2 {
+		return blocks
+	}
+
+	values := make([][]sortValue, len(blocks))
+	for i := range blocks {
+		values[i] = make([]sortValue, len(keys))
+		for k := range keys {
+			values[i][k] = keys[k].value(&blocks[i])
+		}
+	}
+
+	idx := make([]int, len(blocks))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		for k := range keys {
+			c := keys[k].compare(values[idx[i]][k], values[idx[j]][k])
+			if c != 0 {
+				return c < 0
+			}
+		}
+
+		return false
+	})
+
+	sorted := make([]doc.Block, len(blocks))
+	for i := range idx {
+		sorted[i] = blocks[idx[i]]
+	}
+
+	copy(blocks, sorted)
+
+	return blocks
+}
+
+// SortDocument sorts the block lists of the document according to the
+// configuration.
+func SortDocument(document *doc.Document, config SortConfig) {
+	if document == nil {
+		return
+	}
+
+	config.sortList(document.Content, SectionContent, document.Type)
+	config.sortList(document.Meta, SectionMeta, document.Type)
+	config.sortList(document.Links, SectionLinks, document.Type)
+}
+
+func (c SortConfig) sortList(blocks []doc.Block, section Section, parent string) {
+	for _, rule := range c.Rules {
+		if rule.Section != "" && rule.Section != section {
+			continue
+		}
+
+		if rule.Parent != "" && rule.Parent != parent {
+			continue
+		}
+
+		SortBlocks(blocks, rule.Keys...)
+
+		break
+	}
+
+	if !c.Recursive {
+		return
+	}
+
+	for i := range blocks {
+		c.sortList(blocks[i].Content, SectionContent, blocks[i].Type)
+		c.sortList(blocks[i].Meta, SectionMeta, blocks[i].Type)
+		c.sortList(blocks[i].Links, SectionLinks, blocks[i].Type)
+	}
+}
+
+type sortValue struct {
+	known bool
+	str   string
+	num   float64
+	pos   int
+	t     time.Time
+}
+
+func (k SortKey) raw(b *doc.Block) string {
+	if fn, ok := sortFields[k.Field]; ok {
+		return fn(b)
+	}
+
+	return b.Data[strings.TrimPrefix(k.Field, "data.")]
+}
+
+func (k SortKey) value(b *doc.Block) sortValue {
+	raw := k.raw(b)
+
+	if len(k.Order) > 0 {
+		for i, o := range k.Order {
+			if o == raw {
+				return sortValue{known: true, pos: i}
+			}
+		}
+
+		if k.Unknown == UnknownZero {
+			return sortValue{known: true, pos: 0}
+		}
+
+		return sortValue{}
+	}
+
+	if raw == "" {
+		return sortValue{}
+	}
+
+	// Numbers and times are parsed by the doc.Block accessors, the
+	// built-in fields are parsed as if they were data values.
+	block, key := b, strings.TrimPrefix(k.Field, "data.")
+	if _, ok := sortFields[k.Field]; ok {
+		block, key = &doc.Block{Data: map[string]string{k.Field: raw}}, k.Field
+	}
+
+	switch k.As {
+	case SortAsNumber:
+		n, err := block.ParseFloat(key)
+		if err != nil {
+			return sortValue{}
+		}
+
+		return sortValue{known: true, num: n}
+	case SortAsTime:
+		t, err := block.ParseTime(key)
+		if err != nil {
+			return sortValue{}
+		}
+
+		return sortValue{known: true, t: t}
+	case SortAsString, "":
+	}
+
+	return sortValue{known: true, str: raw}
+}
+
+func (k SortKey) compare(a, b sortValue) int {
+	switch {
+	case !a.known && !b.known:
+		return 0
+	case !a.known || !b.known:
+		c := 1
+		if k.Unknown == UnknownFirst {
+			c = -1
+		}
+
+		if !b.known {
+			c = -c
+		}
+
+		return c
+	}
+
+	var c int
+
+	switch {
+	case len(k.Order) > 0:
+		c = a.pos - b.pos
+	case k.As == SortAsNumber:
+		c = compareFloat(a.num, b.num)
+	case k.As == SortAsTime:
+		c = compareTime(a.t, b.t)
+	default:
+		c = strings.Compare(a.str, b.str)
+	}
+
+	if k.Descending {
+		c = -c
+	}
+
+	return c
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+
+	return 0
+}
@@ -70,3 +70,141 @@ func TestBlockSorter_SortBlocks(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockSorterUnknown(t *testing.T) {
+	sorter := navigadoc.NewBlockSorter(map[string]int{"a": 1, "b": 2})
+	sorter.Unknown = navigadoc.UnknownLast
+
+	sorted := sorter.SortBlocks([]doc.Block{{Type: "x"}, {Type: "b"}, {Type: "a"}})
+
+	expected := []string{"a", "b", "x"}
+	for i := range expected {
+		if sorted[i].Type != expected[i] {
+			t.Errorf("expected Type=%s at %d, but was %s", expected[i], i, sorted[i].Type)
+		}
+	}
+}
+
+func TestSortBlocksMultiKey(t *testing.T) {
+	links := []doc.Block{
+		{Rel: "subject", Title: "b"},
+		{Rel: "author", Title: "z"},
+		{Rel: "subject", Title: "a"},
+		{Rel: "channel", Title: "c"},
+		{Rel: "author", Title: "m"},
+	}
+
+	navigadoc.SortBlocks(links,
+		navigadoc.SortKey{Field: "rel", Order: []string{"author", "subject"}},
+		navigadoc.SortKey{Field: "title"},
+	)
+
+	expected := []string{"author/m", "author/z", "subject/a", "subject/b", "channel/c"}
+	for i := range expected {
+		if got := links[i].Rel + "/" + links[i].Title; got != expected[i] {
+			t.Errorf("expected %s at %d, but was %s", expected[i], i, got)
+		}
+	}
+}
+
+func TestSortBlocksTypedData(t *testing.T) {
+	blocks := []doc.Block{
+		{ID: "late", Data: map[string]string{"start": "2020-02-25T08:30:00.000Z", "priority": "10"}},
+		{ID: "missing"},
+		{ID: "early", Data: map[string]string{"start": "2020-02-25T07:30:00+01:00", "priority": "2"}},
+		{ID: "mid", Data: map[string]string{"start": "2020-02-25T07:00:00Z", "priority": "3"}},
+	}
+
+	navigadoc.SortBlocks(blocks, navigadoc.SortKey{Field: "data.start", As: navigadoc.SortAsTime})
+
+	expected := []string{"early", "mid", "late", "missing"}
+	for i := range expected {
+		if blocks[i].ID != expected[i] {
+			t.Errorf("expected %s at %d, but was %s", expected[i], i, blocks[i].ID)
+		}
+	}
+
+	navigadoc.SortBlocks(blocks, navigadoc.SortKey{
+		Field: "data.priority", As: navigadoc.SortAsNumber,
+		Descending: true, Unknown: navigadoc.UnknownFirst,
+	})
+
+	expected = []string{"missing", "late", "mid", "early"}
+	for i := range expected {
+		if blocks[i].ID != expected[i] {
+			t.Errorf("expected %s at %d, but was %s", expected[i], i, blocks[i].ID)
+		}
+	}
+
+	// Values are parsed like the doc.Block accessors do.
+	blocks = []doc.Block{
+		{ID: "nan", Data: map[string]string{"priority": "NaN", "start": "2020-02-25T06:00:00Z"}},
+		{ID: "padded", Data: map[string]string{"priority": " 5 ", "start": " 2020-02-25T09:00:00Z "}},
+		{ID: "plain", Data: map[string]string{"priority": "7", "start": "2020-02-25T08:00:00Z"}},
+	}
+
+	navigadoc.SortBlocks(blocks, navigadoc.SortKey{Field: "data.priority", As: navigadoc.SortAsNumber})
+
+	expected = []string{"padded", "plain", "nan"}
+	for i := range expected {
+		if blocks[i].ID != expected[i] {
+			t.Errorf("expected %s at %d, but was %s", expected[i], i, blocks[i].ID)
+		}
+	}
+
+	navigadoc.SortBlocks(blocks, navigadoc.SortKey{Field: "data.start", As: navigadoc.SortAsTime})
+
+	expected = []string{"nan", "plain", "padded"}
+	for i := range expected {
+		if blocks[i].ID != expected[i] {
+			t.Errorf("expected %s at %d, but was %s", expected[i], i, blocks[i].ID)
+		}
+	}
+}
+
+func TestSortDocumentConfig(t *testing.T) {
+	config, err := navigadoc.LoadSortConfig([]byte(`{
+		"recursive": true,
+		"rules": [
+			{"section": "links", "parent": "x-im/content-part", "keys": [{"field": "title", "descending": true}]},
+			{"section": "links", "keys": [{"field": "rel"}, {"field": "title"}]},
+			{"section": "content", "parent": "x-im/content-part", "keys": [{"field": "id"}]}
+		]
+	}`))
+	must(t, err, "failed to load sort config")
+
+	document := &doc.Document{
+		Type: "x-im/article",
+		Links: []doc.Block{
+			{Rel: "subject", Title: "b"},
+			{Rel: "author", Title: "a"},
+		},
+		Content: []doc.Block{
+			{ID: "z", Type: "x-im/paragraph"},
+			{ID: "part", Type: "x-im/content-part",
+				Links:   []doc.Block{{Title: "a"}, {Title: "b"}},
+				Content: []doc.Block{{ID: "2"}, {ID: "1"}},
+			},
+		},
+	}
+
+	navigadoc.SortDocument(document, *config)
+
+	if document.Links[0].Rel != "author" {
+		t.Error("expected document links to be sorted by rel")
+	}
+
+	if document.Content[0].ID != "z" {
+		t.Error("expected document content to keep its order")
+	}
+
+	part := document.Content[1]
+	if part.Links[0].Title != "b" || part.Content[0].ID != "1" {
+		t.Errorf("expected nested lists to be sorted, got %v %v", part.Links, part.Content)
+	}
+
+	_, err = navigadoc.LoadSortConfig([]byte(`{"rules": [{"keys": [{"field": "colour"}]}]}`))
+	if err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
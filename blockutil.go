@@ -1,6 +1,11 @@
 package navigadoc
 
-import "github.com/navigacontentlab/navigadoc/doc"
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+)
 
 type BlockReplacement interface {
 	GetOldBlock() doc.Block
@@ -23,7 +28,12 @@ func MergeProperties(existingProperties []doc.Property, newProperties []doc.Prop
 	return resultProperties
 }
 
+// DeleteBlocks returns a copy of the document without the blocks
+// matching any of the patterns. The copy doesn't share any maps or
+// slices with the original.
 func DeleteBlocks(document doc.Document, blocksToDelete []doc.Block) *doc.Document {
+	document = *CopyDocument(&document)
+
 	// process links
 	document.Links = GetBlocksToKeep(document.Links, blocksToDelete)
 
@@ -170,7 +180,12 @@ func DeDuplicateLinks(links []doc.Block, linksToDeDuplicate []doc.Block) []doc.B
 	return uniqueList
 }
 
+// ReplaceBlocks returns a copy of the document where the fields of the
+// blocks matching an old block are replaced. The copy doesn't share any
+// maps or slices with the original.
 func ReplaceBlocks(document doc.Document, blocksToReplace []BlockReplacement) *doc.Document {
+	document = *CopyDocument(&document)
+
 	document.Links = replaceBlocksInList(blocksToReplace, document.Links)
 	document.Meta = replaceBlocksInList(blocksToReplace, document.Meta)
 	document.Content = replaceBlocksInList(blocksToReplace, document.Content)
@@ -228,7 +243,7 @@ func replaceBlock(newBlock, block doc.Block) doc.Block {
 		block.Role = newBlock.Role
 	}
 	if len(newBlock.Data) > 0 {
-		block.Data = newBlock.Data
+		block.Data = copyBlock(doc.Block{Data: newBlock.Data}).Data
 	}
 	return block
 }
@@ -242,3 +257,21 @@ func FilterBlocks(blocks []doc.Block, filter func(a doc.Block) bool) []doc.Block
 	}
 	return filteredBlocks
 }
+
+// CopyDocument returns a copy of the document that doesn't share any
+// maps, slices or timestamps with the original.
+func CopyDocument(document *doc.Document) *doc.Document {
+	return document.DeepCopy()
+}
+
+// NewBlockID returns a random 12 character hex block ID.
+func NewBlockID() string {
+	var buf [6]byte
+
+	_, err := rand.Read(buf[:])
+	if err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(buf[:])
+}
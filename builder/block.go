@@ -0,0 +1,158 @@
+package builder
+
+import (
+	"time"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// BlockBuilder builds a doc.Block.
+type BlockBuilder struct {
+	block doc.Block
+}
+
+// NewBlock starts building a block of the given type.
+func NewBlock(blockType string) *BlockBuilder {
+	return &BlockBuilder{block: doc.Block{Type: blockType}}
+}
+
+// Rel starts building a block with a relationship, typically a link.
+func Rel(rel string) *BlockBuilder {
+	return &BlockBuilder{block: doc.Block{Rel: rel}}
+}
+
+// Text starts building a text block, f.ex. an x-im/header, with the
+// text in data.
+func Text(blockType string, text string) *BlockBuilder {
+	return NewBlock(blockType).Data("text", text)
+}
+
+// Paragraph starts building an x-im/paragraph with HTML text.
+func Paragraph(html string) *BlockBuilder {
+	return Text("x-im/paragraph", html).Data("format", "html")
+}
+
+// Block returns a copy of the block built so far.
+func (b *BlockBuilder) Block() doc.Block {
+	c := b.block
+
+	if b.block.Data != nil {
+		c.Data = make(map[string]string, len(b.block.Data))
+		for k, v := range b.block.Data {
+			c.Data[k] = v
+		}
+	}
+
+	c.Links = append([]doc.Block(nil), b.block.Links...)
+	c.Content = append([]doc.Block(nil), b.block.Content...)
+	c.Meta = append([]doc.Block(nil), b.block.Meta...)
+
+	return c
+}
+
+// ID sets the block ID.
+func (b *BlockBuilder) ID(id string) *BlockBuilder {
+	b.block.ID = id
+	return b
+}
+
+// UUID sets the block UUID.
+func (b *BlockBuilder) UUID(uuid string) *BlockBuilder {
+	b.block.UUID = uuid
+	return b
+}
+
+// URI sets the block URI.
+func (b *BlockBuilder) URI(uri string) *BlockBuilder {
+	b.block.URI = uri
+	return b
+}
+
+// URL sets the block URL.
+func (b *BlockBuilder) URL(url string) *BlockBuilder {
+	b.block.URL = url
+	return b
+}
+
+// Type sets the block type.
+func (b *BlockBuilder) Type(blockType string) *BlockBuilder {
+	b.block.Type = blockType
+	return b
+}
+
+// Title sets the block title.
+func (b *BlockBuilder) Title(title string) *BlockBuilder {
+	b.block.Title = title
+	return b
+}
+
+// Rel sets the link relation.
+func (b *BlockBuilder) Rel(rel string) *BlockBuilder {
+	b.block.Rel = rel
+	return b
+}
+
+// Name sets the block name.
+func (b *BlockBuilder) Name(name string) *BlockBuilder {
+	b.block.Name = name
+	return b
+}
+
+// Value sets the block value.
+func (b *BlockBuilder) Value(value string) *BlockBuilder {
+	b.block.Value = value
+	return b
+}
+
+// ContentType sets the block content type.
+func (b *BlockBuilder) ContentType(contentType string) *BlockBuilder {
+	b.block.ContentType = contentType
+	return b
+}
+
+// Role sets the block role.
+func (b *BlockBuilder) Role(role string) *BlockBuilder {
+	b.block.Role = role
+	return b
+}
+
+// Data sets a data value.
+func (b *BlockBuilder) Data(key, value string) *BlockBuilder {
+	if b.block.Data == nil {
+		b.block.Data = make(map[string]string)
+	}
+
+	b.block.Data[key] = value
+
+	return b
+}
+
+// Int sets a data value using doc.Block.SetInt.
+func (b *BlockBuilder) Int(key string, value int) *BlockBuilder {
+	b.block.SetInt(key, value)
+	return b
+}
+
+// Time sets a data value using doc.Block.SetTime.
+func (b *BlockBuilder) Time(key string, value time.Time) *BlockBuilder {
+	b.block.SetTime(key, value)
+	return b
+}
+
+// Link adds links to the block.
+func (b *BlockBuilder) Link(links ...*BlockBuilder) *BlockBuilder {
+	b.block.Links = appendBlocks(b.block.Links, links)
+	return b
+}
+
+// Meta adds meta blocks to the block.
+func (b *BlockBuilder) Meta(meta ...*BlockBuilder) *BlockBuilder {
+	b.block.Meta = appendBlocks(b.block.Meta, meta)
+	return b
+}
+
+// Content adds content blocks to the block.
+func (b *BlockBuilder) Content(content ...*BlockBuilder) *BlockBuilder {
+	b.block.Content = appendBlocks(b.block.Content, content)
+	return b
+}
@@ -0,0 +1,333 @@
+// Package builder provides a fluent API for constructing NavigaDoc
+// documents.
+package builder
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// DocumentBuilder builds a doc.Document. Use Build to get the
+// finished document.
+type DocumentBuilder struct {
+	document   doc.Document
+	now        func() time.Time
+	newID      func() string
+	validate   bool
+	validators []func(*doc.Document) error
+}
+
+// NewDocument starts building a document of the given type.
+func NewDocument(docType string, uuid string) *DocumentBuilder {
+	return &DocumentBuilder{
+		document: doc.Document{
+			Type: docType,
+			UUID: uuid,
+		},
+		now:      time.Now,
+		newID:    RandomID,
+		validate: true,
+	}
+}
+
+// NewArticle starts building an x-im/article with an "im://article/"
+// URI.
+func NewArticle(uuid string) *DocumentBuilder {
+	return NewDocument("x-im/article", uuid).URI("im://article/" + uuid)
+}
+
+// NewImage starts building an x-im/image with an "im://image/" URI.
+func NewImage(uuid string) *DocumentBuilder {
+	return NewDocument("x-im/image", uuid).URI("im://image/" + uuid)
+}
+
+// NewAssignment starts building an x-im/assignment.
+func NewAssignment(uuid string) *DocumentBuilder {
+	return NewDocument("x-im/assignment", uuid)
+}
+
+// NewPlanningItem starts building an x-im/newscoverage.
+func NewPlanningItem(uuid string) *DocumentBuilder {
+	return NewDocument("x-im/newscoverage", uuid)
+}
+
+// NewEvent starts building an x-im/event.
+func NewEvent(uuid string) *DocumentBuilder {
+	return NewDocument("x-im/event", uuid)
+}
+
+// Clock sets the function used for the created and modified
+// timestamps, it defaults to time.Now.
+func (b *DocumentBuilder) Clock(now func() time.Time) *DocumentBuilder {
+	b.now = now
+	return b
+}
+
+// IDGenerator sets the function used to create block IDs, it defaults
+// to RandomID.
+func (b *DocumentBuilder) IDGenerator(newID func() string) *DocumentBuilder {
+	b.newID = newID
+	return b
+}
+
+// SkipValidation makes Build return the document without validating
+// it.
+func (b *DocumentBuilder) SkipValidation() *DocumentBuilder {
+	b.validate = false
+	return b
+}
+
+// Validator adds a validation function that is run by Build after the
+// built-in validation.
+func (b *DocumentBuilder) Validator(fn func(*doc.Document) error) *DocumentBuilder {
+	b.validators = append(b.validators, fn)
+	return b
+}
+
+// Title sets the document title.
+func (b *DocumentBuilder) Title(title string) *DocumentBuilder {
+	b.document.Title = title
+	return b
+}
+
+// URI sets the document URI.
+func (b *DocumentBuilder) URI(uri string) *DocumentBuilder {
+	b.document.URI = uri
+	return b
+}
+
+// URL sets the document URL.
+func (b *DocumentBuilder) URL(url string) *DocumentBuilder {
+	b.document.URL = url
+	return b
+}
+
+// Path sets the document path.
+func (b *DocumentBuilder) Path(path string) *DocumentBuilder {
+	b.document.Path = path
+	return b
+}
+
+// Status sets the document status, f.ex. "usable".
+func (b *DocumentBuilder) Status(status string) *DocumentBuilder {
+	b.document.Status = status
+	return b
+}
+
+// Language sets the document language, f.ex. "sv".
+func (b *DocumentBuilder) Language(language string) *DocumentBuilder {
+	b.document.Language = language
+	return b
+}
+
+// Source sets the document source.
+func (b *DocumentBuilder) Source(source string) *DocumentBuilder {
+	b.document.Source = source
+	return b
+}
+
+// Provider sets the document provider.
+func (b *DocumentBuilder) Provider(provider string) *DocumentBuilder {
+	b.document.Provider = provider
+	return b
+}
+
+// Created sets the created timestamp, it defaults to the clock time.
+func (b *DocumentBuilder) Created(t time.Time) *DocumentBuilder {
+	b.document.Created = &t
+	return b
+}
+
+// Modified sets the modified timestamp, it defaults to the clock time.
+func (b *DocumentBuilder) Modified(t time.Time) *DocumentBuilder {
+	b.document.Modified = &t
+	return b
+}
+
+// Published sets the published timestamp.
+func (b *DocumentBuilder) Published(t time.Time) *DocumentBuilder {
+	b.document.Published = &t
+	return b
+}
+
+// Unpublished sets the unpublished timestamp.
+func (b *DocumentBuilder) Unpublished(t time.Time) *DocumentBuilder {
+	b.document.Unpublished = &t
+	return b
+}
+
+// Property adds a document property.
+func (b *DocumentBuilder) Property(name, value string) *DocumentBuilder {
+	b.document.Properties = append(b.document.Properties, doc.Property{
+		Name:  name,
+		Value: value,
+	})
+
+	return b
+}
+
+// Link adds links to the document.
+func (b *DocumentBuilder) Link(links ...*BlockBuilder) *DocumentBuilder {
+	b.document.Links = appendBlocks(b.document.Links, links)
+	return b
+}
+
+// Meta adds meta blocks to the document.
+func (b *DocumentBuilder) Meta(meta ...*BlockBuilder) *DocumentBuilder {
+	b.document.Meta = appendBlocks(b.document.Meta, meta)
+	return b
+}
+
+// Content adds content blocks to the document.
+func (b *DocumentBuilder) Content(content ...*BlockBuilder) *DocumentBuilder {
+	b.document.Content = appendBlocks(b.document.Content, content)
+	return b
+}
+
+// Header adds an x-im/header content block.
+func (b *DocumentBuilder) Header(text string) *DocumentBuilder {
+	return b.Content(Text("x-im/header", text))
+}
+
+// Preamble adds an x-im/preamble content block.
+func (b *DocumentBuilder) Preamble(text string) *DocumentBuilder {
+	return b.Content(Text("x-im/preamble", text))
+}
+
+// Paragraph adds an x-im/paragraph content block with HTML text.
+func (b *DocumentBuilder) Paragraph(html string) *DocumentBuilder {
+	return b.Content(Paragraph(html))
+}
+
+// Build finishes the document. Content and meta blocks without an ID
+// are given one, UUIDs are lowercased and missing created and modified
+// timestamps are set. Unless SkipValidation has been called the
+// document is validated against the NavigaDoc schema, its content
+// profile and the added validators.
+//
+// The builder can be reused, every call to Build returns a new
+// document.
+func (b *DocumentBuilder) Build() (*doc.Document, error) {
+	document := navigadoc.CopyDocument(&b.document)
+
+	now := b.now().UTC().Truncate(time.Millisecond)
+
+	if document.Created == nil {
+		created := now
+		document.Created = &created
+	}
+
+	if document.Modified == nil {
+		modified := now
+		document.Modified = &modified
+	}
+
+	document.UUID = strings.ToLower(document.UUID)
+
+	b.assignIDs(document.Content)
+	b.assignIDs(document.Meta)
+
+	_ = navigadoc.Walk(document, func(block *doc.Block, _ *navigadoc.Cursor) (navigadoc.WalkAction, error) {
+		block.UUID = strings.ToLower(block.UUID)
+		return navigadoc.WalkContinue, nil
+	})
+
+	if !b.validate {
+		return document, nil
+	}
+
+	err := b.runValidation(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// MustBuild is like Build but panics on errors. It's intended for
+// tests and package level fixtures.
+func (b *DocumentBuilder) MustBuild() *doc.Document {
+	document, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+
+	return document
+}
+
+func (b *DocumentBuilder) runValidation(document *doc.Document) error {
+	var errs navigadoc.ValidationErrors
+
+	v, err := navigadoc.NewValidator()
+	if err != nil {
+		return err
+	}
+
+	schemaErrs, err := v.ValidateDocument(document)
+	if err != nil {
+		return err
+	}
+
+	errs = append(errs, schemaErrs...)
+
+	for _, fn := range []func(*doc.Document) error{
+		navigadoc.ValidateBlocks,
+		navigadoc.ValidateProfile,
+	} {
+		var verrs navigadoc.ValidationErrors
+
+		err := fn(document)
+		if err == nil {
+			continue
+		}
+
+		if !errors.As(err, &verrs) {
+			return err
+		}
+
+		errs = append(errs, verrs...)
+	}
+
+	if len(errs.Severe()) > 0 {
+		return errs
+	}
+
+	for _, fn := range b.validators {
+		err := fn(document)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// assignIDs gives content and meta blocks without an ID a new one.
+// Links are left alone as they usually don't carry IDs.
+func (b *DocumentBuilder) assignIDs(blocks []doc.Block) {
+	for i := range blocks {
+		if blocks[i].ID == "" {
+			blocks[i].ID = b.newID()
+		}
+
+		b.assignIDs(blocks[i].Content)
+		b.assignIDs(blocks[i].Meta)
+	}
+}
+
+// RandomID returns a random block ID, see navigadoc.NewBlockID.
+func RandomID() string {
+	return navigadoc.NewBlockID()
+}
+
+func appendBlocks(blocks []doc.Block, builders []*BlockBuilder) []doc.Block {
+	for i := range builders {
+		blocks = append(blocks, builders[i].Block())
+	}
+
+	return blocks
+}
@@ -0,0 +1,147 @@
+package builder_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/builder"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+func sequentialIDs() func() string {
+	n := 0
+
+	return func() string {
+		n++
+		return fmt.Sprintf("block-%d", n)
+	}
+}
+
+func TestBuildArticle(t *testing.T) {
+	now := time.Date(2020, 2, 25, 6, 30, 0, 123456789, time.FixedZone("", 3600))
+
+	document, err := builder.NewArticle("1D02738F-7C99-42BA-A6DA-3D1B97261523").
+		Clock(func() time.Time { return now }).
+		IDGenerator(sequentialIDs()).
+		Title("Lorem ipsum").
+		Status("draft").
+		Property("subtype", "x-im/print").
+		Link(builder.Rel("author").Type("x-im/author").Title("Jane").
+			UUID("77FE8F0C-4AAF-4B5D-8369-08DAC4A67878")).
+		Meta(builder.NewBlock("x-im/newsvalue").Int("score", 3)).
+		Header("Lorem ipsum").
+		Paragraph("Dolor <strong>sit</strong> amet").
+		Content(builder.NewBlock("x-im/content-part").ID("part").
+			Content(builder.Paragraph("Nested"))).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if document.UUID != "1d02738f-7c99-42ba-a6da-3d1b97261523" {
+		t.Errorf("expected lowercased document UUID, was %s", document.UUID)
+	}
+
+	if document.URI != "im://article/1D02738F-7C99-42BA-A6DA-3D1B97261523" {
+		t.Errorf("unexpected URI %s", document.URI)
+	}
+
+	if document.Links[0].UUID != "77fe8f0c-4aaf-4b5d-8369-08dac4a67878" {
+		t.Errorf("expected lowercased link UUID, was %s", document.Links[0].UUID)
+	}
+
+	if document.Links[0].ID != "" {
+		t.Error("expected links to be left without IDs")
+	}
+
+	expected := now.UTC().Truncate(time.Millisecond)
+	if document.Created == nil || !document.Created.Equal(expected) || document.Created.Location() != time.UTC {
+		t.Errorf("expected created %v, was %v", expected, document.Created)
+	}
+
+	if document.Modified == nil || !document.Modified.Equal(expected) {
+		t.Errorf("expected modified %v, was %v", expected, document.Modified)
+	}
+
+	ids := []string{
+		document.Content[0].ID,
+		document.Content[1].ID,
+		document.Content[2].ID,
+		document.Content[2].Content[0].ID,
+		document.Meta[0].ID,
+	}
+	expectedIDs := []string{"block-1", "block-2", "part", "block-3", "block-4"}
+
+	for i := range ids {
+		if ids[i] != expectedIDs[i] {
+			t.Errorf("expected ID %s, was %s", expectedIDs[i], ids[i])
+		}
+	}
+
+	if document.Content[1].Data["format"] != "html" {
+		t.Error("expected paragraph to have the html format")
+	}
+
+	if document.Meta[0].Data["score"] != "3" {
+		t.Errorf("expected score 3, was %q", document.Meta[0].Data["score"])
+	}
+}
+
+func TestBuildValidation(t *testing.T) {
+	b := builder.NewImage("e09aaeb8-27d9-4e3e-a9aa-f79f4c460ba4").
+		Link(builder.Rel("creator").Type("x-imid/user").UUID("not-a-uuid"))
+
+	_, err := b.Build()
+
+	var verrs navigadoc.ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+
+	codes := make(map[navigadoc.ErrorCode]bool)
+	for _, e := range verrs {
+		codes[e.Code] = true
+	}
+
+	if !codes[navigadoc.CodeMissingMeta] || !codes[navigadoc.CodeInvalidUUID] {
+		t.Errorf("expected missing meta and invalid uuid errors, got %v", verrs)
+	}
+
+	document, err := b.SkipValidation().Build()
+	if err != nil || document == nil {
+		t.Errorf("expected document without validation, got %v", err)
+	}
+}
+
+func TestBuildCustomValidator(t *testing.T) {
+	errNoTitle := errors.New("no title")
+
+	_, err := builder.NewArticle("1d02738f-7c99-42ba-a6da-3d1b97261523").
+		Validator(func(d *doc.Document) error {
+			if d.Title == "" {
+				return errNoTitle
+			}
+
+			return nil
+		}).
+		Build()
+	if !errors.Is(err, errNoTitle) {
+		t.Errorf("expected custom validator error, got %v", err)
+	}
+}
+
+func TestBuildReuse(t *testing.T) {
+	b := builder.NewArticle("1d02738f-7c99-42ba-a6da-3d1b97261523").
+		Meta(builder.NewBlock("x-im/newsvalue").Data("score", "1"))
+
+	first := b.MustBuild()
+	first.Meta[0].Data["score"] = "5"
+
+	second := b.MustBuild()
+	if second.Meta[0].Data["score"] != "1" {
+		t.Error("expected builds to not share data maps")
+	}
+}
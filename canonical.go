@@ -0,0 +1,217 @@
+package navigadoc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// Canonicalize returns a copy of the document in a stable normal form:
+//
+//   - UUIDs are lowercased
+//   - properties are sorted by name and then value
+//   - empty slices and maps are removed
+//   - timestamps are converted to UTC
+//   - content and meta blocks without an ID are given one derived from
+//     their position and type
+//
+// Block order is significant and is left as is. Canonicalizing an
+// already canonical document returns an equal document.
+func Canonicalize(document *doc.Document) *doc.Document {
+	if document == nil {
+		return nil
+	}
+
+	c := CopyDocument(document)
+
+	c.UUID = strings.ToLower(c.UUID)
+	c.Created = utcTime(c.Created)
+	c.Modified = utcTime(c.Modified)
+	c.Published = utcTime(c.Published)
+	c.Unpublished = utcTime(c.Unpublished)
+
+	if len(c.Products) == 0 {
+		c.Products = nil
+	}
+
+	c.Properties = canonicalProperties(c.Properties)
+	c.Content = canonicalBlocks(c.Content, "/content", true)
+	c.Meta = canonicalBlocks(c.Meta, "/meta", true)
+	c.Links = canonicalBlocks(c.Links, "/links", false)
+
+	return c
+}
+
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	u := t.UTC()
+
+	return &u
+}
+
+func canonicalProperties(properties []doc.Property) []doc.Property {
+	if len(properties) == 0 {
+		return nil
+	}
+
+	for i := range properties {
+		if len(properties[i].Parameters) == 0 {
+			properties[i].Parameters = nil
+		}
+	}
+
+	sort.SliceStable(properties, func(i, j int) bool {
+		if properties[i].Name != properties[j].Name {
+			return properties[i].Name < properties[j].Name
+		}
+
+		if properties[i].Value != properties[j].Value {
+			return properties[i].Value < properties[j].Value
+		}
+
+		return lessParameters(properties[i].Parameters, properties[j].Parameters)
+	})
+
+	return properties
+}
+
+// lessParameters orders property parameters on their sorted keys and
+// values, so that properties that only differ in their parameters get
+// a stable order.
+func lessParameters(a, b map[string]string) bool {
+	aKeys := parameterKeys(a)
+	bKeys := parameterKeys(b)
+
+	for i := 0; i < len(aKeys) && i < len(bKeys); i++ {
+		if aKeys[i] != bKeys[i] {
+			return aKeys[i] < bKeys[i]
+		}
+
+		if a[aKeys[i]] != b[bKeys[i]] {
+			return a[aKeys[i]] < b[bKeys[i]]
+		}
+	}
+
+	return len(aKeys) < len(bKeys)
+}
+
+func parameterKeys(parameters map[string]string) []string {
+	keys := make([]string, 0, len(parameters))
+	for k := range parameters {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func canonicalBlocks(blocks []doc.Block, path string, assignIDs bool) []doc.Block {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	for i := range blocks {
+		b := &blocks[i]
+		blockPath := fmt.Sprintf("%s/%d", path, i)
+
+		b.UUID = strings.ToLower(b.UUID)
+
+		if len(b.Data) == 0 {
+			b.Data = nil
+		}
+
+		if assignIDs && b.ID == "" {
+			b.ID = canonicalID(blockPath, b.Type)
+		}
+
+		b.Content = canonicalBlocks(b.Content, blockPath+"/content", true)
+		b.Meta = canonicalBlocks(b.Meta, blockPath+"/meta", true)
+		b.Links = canonicalBlocks(b.Links, blockPath+"/links", false)
+	}
+
+	return blocks
+}
+
+// canonicalID derives a block ID from the position and type of the
+// block, so that the same document always gets the same IDs.
+func canonicalID(path, blockType string) string {
+	sum := sha256.Sum256([]byte(path + "\x00" + blockType))
+
+	return hex.EncodeToString(sum[:6])
+}
+
+// HashOption configures Hash.
+type HashOption func(o *hashOptions)
+
+type hashOptions struct {
+	exclude map[string]bool
+}
+
+// HashExclude leaves the given document fields out of the hash. Fields
+// are named by their JSON name, f.ex. "modified".
+func HashExclude(fields ...string) HashOption {
+	return func(o *hashOptions) {
+		for _, f := range fields {
+			o.exclude[f] = true
+		}
+	}
+}
+
+// HashExcludeVolatile leaves out the fields that change without the
+// content changing, that is "modified".
+func HashExcludeVolatile() HashOption {
+	return HashExclude("modified")
+}
+
+// Hash returns a hex encoded SHA-256 fingerprint of the canonical form
+// of the document. Documents that only differ in what Canonicalize
+// normalizes get the same hash.
+func Hash(document *doc.Document, opts ...HashOption) (string, error) {
+	o := hashOptions{exclude: make(map[string]bool)}
+
+	for i := range opts {
+		opts[i](&o)
+	}
+
+	if document == nil {
+		document = &doc.Document{}
+	}
+
+	data, err := json.Marshal(Canonicalize(document))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	if len(o.exclude) > 0 {
+		var fields map[string]json.RawMessage
+
+		err = json.Unmarshal(data, &fields)
+		if err != nil {
+			return "", fmt.Errorf("failed to unmarshal document: %w", err)
+		}
+
+		for f := range o.exclude {
+			delete(fields, f)
+		}
+
+		// Map keys are sorted by json.Marshal.
+		data, err = json.Marshal(fields)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal document: %w", err)
+		}
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,137 @@
+package navigadoc_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+func TestCanonicalize(t *testing.T) {
+	document := loadDocument(t, "./testdata/uuids-uppercase.json")
+	document.Properties = append(document.Properties, doc.Property{
+		Name: "a-first", Parameters: map[string]string{},
+	})
+	document.Content = append(document.Content, doc.Block{Type: "x-im/paragraph", Data: map[string]string{}})
+	document.Products = []string{}
+
+	canonical := navigadoc.Canonicalize(document)
+
+	if canonical.UUID != strings.ToLower(document.UUID) {
+		t.Errorf("expected lowercased UUID, was %s", canonical.UUID)
+	}
+
+	for _, l := range canonical.Links {
+		if l.UUID != strings.ToLower(l.UUID) {
+			t.Errorf("expected lowercased link UUID, was %s", l.UUID)
+		}
+	}
+
+	if canonical.Properties[0].Name != "a-first" || canonical.Properties[0].Parameters != nil {
+		t.Errorf("expected sorted and trimmed properties, got %v", canonical.Properties)
+	}
+
+	if canonical.Products != nil {
+		t.Error("expected empty products to be removed")
+	}
+
+	added := canonical.Content[len(canonical.Content)-1]
+	if added.ID == "" || added.Data != nil {
+		t.Errorf("expected ID and no data on added block, got %+v", added)
+	}
+
+	if canonical.Published.Location() != time.UTC {
+		t.Error("expected timestamps in UTC")
+	}
+
+	if document.Content[len(document.Content)-1].ID != "" {
+		t.Error("expected the input document to be left unchanged")
+	}
+
+	again := navigadoc.Canonicalize(canonical)
+	if !reflect.DeepEqual(canonical, again) {
+		t.Error("expected canonicalization to be idempotent")
+	}
+}
+
+func TestCanonicalizePropertyParameters(t *testing.T) {
+	a := &doc.Document{Properties: []doc.Property{
+		{Name: "definition", Value: "Blues", Parameters: map[string]string{"role": "drol:short"}},
+		{Name: "definition", Value: "Blues", Parameters: map[string]string{"role": "drol:long"}},
+	}}
+	b := &doc.Document{Properties: []doc.Property{a.Properties[1], a.Properties[0]}}
+
+	ca := navigadoc.Canonicalize(a)
+	cb := navigadoc.Canonicalize(b)
+
+	if !reflect.DeepEqual(ca.Properties, cb.Properties) {
+		t.Errorf("expected the same property order, got %v and %v", ca.Properties, cb.Properties)
+	}
+
+	if ca.Properties[0].Parameters["role"] != "drol:long" {
+		t.Errorf("expected properties to be sorted on parameters, got %v", ca.Properties)
+	}
+
+	hashA, err := navigadoc.Hash(a)
+	must(t, err, "failed to hash a")
+
+	hashB, err := navigadoc.Hash(b)
+	must(t, err, "failed to hash b")
+
+	if hashA != hashB {
+		t.Error("expected equal hashes for reordered properties")
+	}
+}
+
+func TestHash(t *testing.T) {
+	a := loadDocument(t, "./testdata/text.json")
+	b := loadDocument(t, "./testdata/text.json")
+
+	b.UUID = strings.ToUpper(b.UUID)
+	b.Properties[0], b.Properties[1] = b.Properties[1], b.Properties[0]
+
+	published := b.Published.In(time.FixedZone("", -5*3600))
+	b.Published = &published
+
+	hashA, err := navigadoc.Hash(a)
+	must(t, err, "failed to hash a")
+
+	hashB, err := navigadoc.Hash(b)
+	must(t, err, "failed to hash b")
+
+	if hashA != hashB {
+		t.Error("expected equal hashes for equivalent documents")
+	}
+
+	modified := b.Modified.Add(time.Hour)
+	b.Modified = &modified
+
+	hashB, err = navigadoc.Hash(b)
+	must(t, err, "failed to hash b")
+
+	if hashA == hashB {
+		t.Error("expected modified to change the hash")
+	}
+
+	hashA, err = navigadoc.Hash(a, navigadoc.HashExcludeVolatile())
+	must(t, err, "failed to hash a")
+
+	hashB, err = navigadoc.Hash(b, navigadoc.HashExcludeVolatile())
+	must(t, err, "failed to hash b")
+
+	if hashA != hashB {
+		t.Error("expected equal hashes when excluding modified")
+	}
+
+	b.Title = "Changed"
+
+	hashB, err = navigadoc.Hash(b, navigadoc.HashExcludeVolatile())
+	must(t, err, "failed to hash b")
+
+	if hashA == hashB {
+		t.Error("expected title to change the hash")
+	}
+}
@@ -0,0 +1,203 @@
+package navigadoc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// CloneOptions controls how Clone remaps the identity of a document.
+type CloneOptions struct {
+	// UUID is the UUID of the clone, a random v4 UUID is used if it's
+	// empty.
+	UUID string
+	// URI is the URI of the clone. If it's empty and the original URI
+	// contains the original UUID, the UUID in it is replaced. It must be
+	// set if the original has a URI without the UUID.
+	URI string
+	// KeepIDs leaves block IDs as they are.
+	KeepIDs bool
+	// NewID creates the new ID for a block, it defaults to NewBlockID.
+	NewID func(oldID string) string
+	// ReferenceKeys are the Data keys that hold the ID of another block
+	// in the document, or a comma separated list of IDs. Their values
+	// are rewritten to the new IDs, other Data values are left as is.
+	ReferenceKeys []string
+	// TypeReferenceKeys are reference keys per block type, the "" entry
+	// applies to all types. It defaults to DefaultReferenceKeys, extend
+	// the map returned by it to add keys for other types.
+	TypeReferenceKeys map[string][]string
+	// Now, if set, is used for the created and modified timestamps of
+	// the clone, and the published and unpublished timestamps are
+	// cleared.
+	Now func() time.Time
+}
+
+// CloneMapping describes how the identity of a document was remapped
+// by Clone.
+type CloneMapping struct {
+	OldUUID string
+	NewUUID string
+	OldURI  string
+	NewURI  string
+	// IDs maps old block IDs to new ones.
+	IDs map[string]string
+}
+
+// DefaultReferenceKeys returns the reference keys that Clone uses when
+// CloneOptions.TypeReferenceKeys isn't set. The "" entry applies to all
+// block types and holds the conventional "ref" and "refs" keys.
+func DefaultReferenceKeys() map[string][]string {
+	return map[string][]string{
+		"": {"ref", "refs"},
+	}
+}
+
+// Clone returns a deep copy of the document with a new UUID, URI and
+// block IDs.
+//
+// References to the document itself, that is blocks with the
+// document's UUID or URI, are pointed at the clone. The Data values of
+// the reference keys are rewritten to the new block IDs. Other UUIDs,
+// URIs and values are left untouched.
+func Clone(document *doc.Document, opts CloneOptions) (*doc.Document, CloneMapping, error) {
+	if document == nil {
+		return nil, CloneMapping{}, ErrEmptyDoc
+	}
+
+	clone := CopyDocument(document)
+
+	mapping := CloneMapping{
+		OldUUID: document.UUID,
+		NewUUID: opts.UUID,
+		OldURI:  document.URI,
+		NewURI:  opts.URI,
+		IDs:     make(map[string]string),
+	}
+
+	if mapping.NewUUID == "" {
+		mapping.NewUUID = uuid.New().String()
+	} else if err := ValidateUUID(mapping.NewUUID); err != nil {
+		return nil, CloneMapping{}, InvalidArgumentError{
+			Msg: fmt.Sprintf("clone uuid %s: %v", mapping.NewUUID, err),
+			Err: err,
+		}
+	}
+
+	if mapping.NewURI == "" && document.URI != "" {
+		uri, ok := replaceFold(document.URI, document.UUID, mapping.NewUUID)
+		if !ok {
+			return nil, CloneMapping{}, InvalidArgumentError{
+				Msg: fmt.Sprintf("clone uri: %s doesn't contain the uuid, set CloneOptions.URI", document.URI),
+			}
+		}
+
+		mapping.NewURI = uri
+	}
+
+	clone.UUID = mapping.NewUUID
+	clone.URI = mapping.NewURI
+
+	if opts.Now != nil {
+		now := opts.Now()
+		modified := now
+
+		clone.Created = &now
+		clone.Modified = &modified
+		clone.Published = nil
+		clone.Unpublished = nil
+	}
+
+	newID := opts.NewID
+	if newID == nil {
+		newID = func(string) string { return NewBlockID() }
+	}
+
+	if !opts.KeepIDs {
+		_ = Walk(clone, func(block *doc.Block, _ *Cursor) (WalkAction, error) {
+			if block.ID == "" {
+				return WalkContinue, nil
+			}
+
+			n, ok := mapping.IDs[block.ID]
+			if !ok {
+				n = newID(block.ID)
+				mapping.IDs[block.ID] = n
+			}
+
+			block.ID = n
+
+			return WalkContinue, nil
+		})
+	}
+
+	typeKeys := opts.TypeReferenceKeys
+	if typeKeys == nil {
+		typeKeys = DefaultReferenceKeys()
+	}
+
+	_ = Walk(clone, func(block *doc.Block, _ *Cursor) (WalkAction, error) {
+		if mapping.OldUUID != "" && strings.EqualFold(block.UUID, mapping.OldUUID) {
+			block.UUID = mapping.NewUUID
+		}
+
+		if mapping.OldURI != "" && block.URI == mapping.OldURI {
+			block.URI = mapping.NewURI
+		}
+
+		for _, keys := range [][]string{opts.ReferenceKeys, typeKeys[""], typeKeys[block.Type]} {
+			for _, k := range keys {
+				if v, ok := block.Data[k]; ok {
+					block.Data[k] = remapIDs(v, mapping.IDs)
+				}
+			}
+		}
+
+		return WalkContinue, nil
+	})
+
+	return clone, mapping, nil
+}
+
+// remapIDs rewrites a value that is an ID, or a comma separated list
+// of IDs, using the mapping. Other values are returned as is.
+func remapIDs(value string, ids map[string]string) string {
+	if value == "" || len(ids) == 0 {
+		return value
+	}
+
+	if n, ok := ids[value]; ok {
+		return n
+	}
+
+	if !strings.Contains(value, ",") {
+		return value
+	}
+
+	parts := strings.Split(value, ",")
+
+	for i := range parts {
+		n, ok := ids[strings.TrimSpace(parts[i])]
+		if !ok {
+			return value
+		}
+
+		parts[i] = strings.Replace(parts[i], strings.TrimSpace(parts[i]), n, 1)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// replaceFold replaces the first occurrence of old in s, ignoring
+// case. It returns false if s doesn't contain old.
+func replaceFold(s, old, replacement string) (string, bool) {
+	i := strings.Index(strings.ToLower(s), strings.ToLower(old))
+	if old == "" || i < 0 {
+		return "", false
+	}
+
+	return s[:i] + replacement + s[i+len(old):], true
+}
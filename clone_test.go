@@ -0,0 +1,251 @@
+package navigadoc_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+func TestClone(t *testing.T) {
+	document := loadDocument(t, "./testdata/text.json")
+	document.Meta = append(document.Meta, doc.Block{
+		ID:   "ref-meta",
+		Type: "x-im/teaser-ref",
+		Data: map[string]string{
+			"teaser": document.Meta[2].ID,
+			"items":  document.Meta[0].ID + ", " + document.Meta[1].ID,
+			"other":  "not-an-id",
+		},
+	})
+	document.Links = append(document.Links, doc.Block{
+		Rel: "self", Type: document.Type, UUID: document.UUID, URI: document.URI,
+	})
+
+	newUUID := "5c82df45-73b6-4b4e-a946-4645e5e11bba"
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	clone, mapping, err := navigadoc.Clone(document, navigadoc.CloneOptions{
+		UUID:          newUUID,
+		Now:           func() time.Time { return now },
+		ReferenceKeys: []string{"teaser", "items"},
+	})
+	must(t, err, "failed to clone")
+
+	if clone.UUID != newUUID || clone.URI != "im://article/"+newUUID {
+		t.Errorf("unexpected identity %s %s", clone.UUID, clone.URI)
+	}
+
+	if mapping.OldUUID != document.UUID || mapping.NewURI != clone.URI {
+		t.Errorf("unexpected mapping %+v", mapping)
+	}
+
+	if !clone.Created.Equal(now) || clone.Published != nil {
+		t.Error("expected timestamps to be reset")
+	}
+
+	for i := range document.Meta {
+		old := document.Meta[i].ID
+		if clone.Meta[i].ID == old || clone.Meta[i].ID != mapping.IDs[old] {
+			t.Errorf("expected meta %d to get a new mapped ID", i)
+		}
+	}
+
+	ref := clone.Meta[len(clone.Meta)-1]
+	if ref.Data["teaser"] != clone.Meta[2].ID {
+		t.Errorf("expected teaser reference to be rewritten, was %s", ref.Data["teaser"])
+	}
+
+	if ref.Data["items"] != clone.Meta[0].ID+", "+clone.Meta[1].ID {
+		t.Errorf("expected item list to be rewritten, was %s", ref.Data["items"])
+	}
+
+	if ref.Data["other"] != "not-an-id" {
+		t.Error("expected unrelated data to be kept")
+	}
+
+	self := clone.Links[len(clone.Links)-1]
+	if self.UUID != newUUID || self.URI != clone.URI {
+		t.Error("expected the self link to point at the clone")
+	}
+
+	if clone.Links[0].UUID != document.Links[0].UUID {
+		t.Error("expected external link UUIDs to be kept")
+	}
+
+	clone.Meta[0].Data["score"] = "changed"
+	clone.Properties[0].Value = "changed"
+
+	if document.Meta[0].Data["score"] == "changed" || document.Properties[0].Value == "changed" {
+		t.Error("expected the clone to not share data with the original")
+	}
+}
+
+func TestCloneOptions(t *testing.T) {
+	document := loadDocument(t, "./testdata/objecttexttocontent.json")
+
+	clone, mapping, err := navigadoc.Clone(document, navigadoc.CloneOptions{KeepIDs: true})
+	must(t, err, "failed to clone")
+
+	if clone.UUID == document.UUID || clone.UUID == "" {
+		t.Error("expected a new random UUID")
+	}
+
+	if len(mapping.IDs) != 0 || clone.Content[0].ID != document.Content[0].ID {
+		t.Error("expected IDs to be kept")
+	}
+
+	if !clone.Created.Equal(*document.Created) {
+		t.Error("expected timestamps to be kept")
+	}
+
+	_, _, err = navigadoc.Clone(document, navigadoc.CloneOptions{UUID: "nope"})
+	if !errors.Is(err, navigadoc.InvalidArgumentError{}) {
+		t.Errorf("expected InvalidArgumentError, got %v", err)
+	}
+}
+
+func TestCloneNumericIDs(t *testing.T) {
+	document := &doc.Document{
+		UUID: "5c82df45-73b6-4b4e-a946-4645e5e11bba",
+		Content: []doc.Block{
+			{ID: "1", Type: "x-im/table", Value: "2", Data: map[string]string{
+				"columns": "2",
+				"rows":    "1",
+			}},
+			{ID: "2", Type: "x-im/teaser-ref", Data: map[string]string{
+				"teaser": "1",
+			}},
+		},
+	}
+
+	clone, mapping, err := navigadoc.Clone(document, navigadoc.CloneOptions{
+		ReferenceKeys: []string{"teaser"},
+	})
+	must(t, err, "failed to clone")
+
+	table := clone.Content[0]
+	if table.Data["columns"] != "2" || table.Data["rows"] != "1" || table.Value != "2" {
+		t.Errorf("expected numeric values to be kept, got %v and %q", table.Data, table.Value)
+	}
+
+	if clone.Content[1].Data["teaser"] != mapping.IDs["1"] {
+		t.Errorf("expected the teaser reference to be rewritten, was %s", clone.Content[1].Data["teaser"])
+	}
+}
+
+func TestCloneDefaultReferenceKeys(t *testing.T) {
+	files, err := filepath.Glob("./testdata/*.json")
+	must(t, err, "could not list testdata")
+
+	for _, file := range files {
+		name := filepath.Base(file)
+
+		// event.json contains comments and empty-document-example.json
+		// has no document.
+		if name == "event.json" || name == "empty-document-example.json" {
+			continue
+		}
+
+		t.Run(name, func(t *testing.T) {
+			document := loadDocument(t, file)
+
+			// Reference the first block with an ID from a content and
+			// a meta block.
+			var target string
+
+			_ = navigadoc.Walk(document, func(block *doc.Block, _ *navigadoc.Cursor) (navigadoc.WalkAction, error) {
+				if target == "" && block.ID != "" {
+					target = block.ID
+				}
+
+				return navigadoc.WalkContinue, nil
+			})
+
+			if target != "" {
+				document.Content = append(document.Content, doc.Block{
+					ID: "ref-content", Type: "x-im/teaser", Data: map[string]string{"ref": target},
+				})
+				document.Meta = append(document.Meta, doc.Block{
+					ID: "ref-meta", Type: "x-im/list", Data: map[string]string{"refs": target + ",ref-content"},
+				})
+			}
+
+			opts := navigadoc.CloneOptions{}
+			if document.URI != "" && !strings.Contains(document.URI, document.UUID) {
+				opts.URI = "im://clone/" + name
+			}
+
+			clone, mapping, err := navigadoc.Clone(document, opts)
+			must(t, err, "failed to clone")
+
+			_ = navigadoc.Walk(clone, func(block *doc.Block, cursor *navigadoc.Cursor) (navigadoc.WalkAction, error) {
+				if _, old := mapping.IDs[block.ID]; old {
+					t.Errorf("%s: the old ID %s survived", cursor.Path, block.ID)
+				}
+
+				for _, k := range navigadoc.DefaultReferenceKeys()[""] {
+					for _, id := range strings.Split(block.Data[k], ",") {
+						if _, old := mapping.IDs[id]; old {
+							t.Errorf("%s: the reference %s to an old ID survived", cursor.Path, k)
+						}
+					}
+				}
+
+				return navigadoc.WalkContinue, nil
+			})
+		})
+	}
+}
+
+func TestCloneURI(t *testing.T) {
+	document := &doc.Document{
+		UUID: "5c82df45-73b6-4b4e-a946-4645e5e11bba",
+		URI:  "im://template/weather",
+	}
+
+	_, _, err := navigadoc.Clone(document, navigadoc.CloneOptions{})
+	if !errors.Is(err, navigadoc.InvalidArgumentError{}) {
+		t.Errorf("expected InvalidArgumentError for a URI without the UUID, got %v", err)
+	}
+
+	clone, mapping, err := navigadoc.Clone(document, navigadoc.CloneOptions{URI: "im://template/weather-copy"})
+	must(t, err, "failed to clone")
+
+	if clone.URI != "im://template/weather-copy" || mapping.NewURI != clone.URI {
+		t.Errorf("unexpected uri %s", clone.URI)
+	}
+
+	keys := navigadoc.DefaultReferenceKeys()
+	keys["x-im/teaser-ref"] = []string{"teaser"}
+
+	document.Content = []doc.Block{
+		{ID: "teaser", Type: "x-im/teaser"},
+		{ID: "ref", Type: "x-im/teaser-ref", Data: map[string]string{"teaser": "teaser"}},
+	}
+
+	clone, mapping, err = navigadoc.Clone(document, navigadoc.CloneOptions{
+		URI:               "im://template/weather-copy",
+		TypeReferenceKeys: keys,
+	})
+	must(t, err, "failed to clone")
+
+	if clone.Content[1].Data["teaser"] != mapping.IDs["teaser"] {
+		t.Errorf("expected the added type key to be rewritten, was %s", clone.Content[1].Data["teaser"])
+	}
+}
+
+func TestDeleteBlocksCopies(t *testing.T) {
+	document := loadDocument(t, "./testdata/text.json")
+
+	result := navigadoc.DeleteBlocks(*document, []doc.Block{{Type: "x-im/teaser"}})
+	result.Meta[0].Data["score"] = "changed"
+
+	if document.Meta[0].Data["score"] == "changed" {
+		t.Error("expected DeleteBlocks to not share data maps")
+	}
+}
@@ -0,0 +1,469 @@
+package main
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// converter writes the FromDoc and ToDoc methods of the rpc messages
+// and the helpers they use.
+type converter struct {
+	helpers
+
+	g *protogen.GeneratedFile
+	m *Model
+}
+
+func generateConversion(g *protogen.GeneratedFile, m *Model, pkg protogen.GoPackageName) {
+	c := converter{g: g, m: m}
+
+	g.P(generatedHeader)
+	g.P()
+	g.P("package ", pkg)
+	g.P()
+
+	for _, msg := range m.Messages {
+		c.method(msg, "from")
+	}
+
+	for _, msg := range m.Messages {
+		c.method(msg, "to")
+	}
+
+	c.flush()
+}
+
+func (c *converter) method(msg *Message, dir string) {
+	g := c.g
+	docType := g.QualifiedGoIdent(c.m.DocPackage.Ident(msg.Name))
+
+	if dir == "from" {
+		g.P("// FromDoc", msg.Name, " sets the message from a doc.", msg.Name, ".")
+		g.P("func (d *", msg.RPC.GoName, ") FromDoc", msg.Name, "(doc *", docType, ") error {")
+	} else {
+		g.P("// ToDoc", msg.Name, " sets the fields of a doc.", msg.Name, " from the message.")
+		g.P("func (d *", msg.RPC.GoName, ") ToDoc", msg.Name, "(doc *", docType, ") error {")
+	}
+
+	for _, f := range msg.Fields {
+		if !f.Direct() {
+			g.P("var err error")
+			g.P()
+
+			break
+		}
+	}
+
+	if dir == "from" && msg.EmptyCollections != "" {
+		g.P("d.", msg.EmptyCollections, " = nil")
+	}
+
+	for _, f := range msg.Fields {
+		to, from := "d."+f.RPCName, "doc."+f.Name
+		if dir == "to" {
+			to, from = from, to
+		}
+
+		switch {
+		case f.Direct():
+			g.P(to, " = ", from)
+		case dir == "to" && f.OffsetRPCName != "":
+			g.P(to, ", err = ", c.offsetHelpers(f.Elem, dir), "(", from, ", d.", f.OffsetRPCName, ")")
+		default:
+			g.P(to, ", err = ", c.fieldHelper(f, dir), "(", from, ")")
+		}
+
+		if !f.Direct() {
+			g.P("if err != nil {")
+			g.P(`return `, fmtErrorf, `("`, f.JSONName, `: %w", err)`)
+			g.P("}")
+		}
+
+		if dir == "from" && f.OffsetRPCName != "" {
+			g.P("d.", f.OffsetRPCName, " = ", c.offsetHelpers(f.Elem, dir), "(doc.", f.Name, ")")
+		}
+
+		if msg.EmptyCollections != "" && f.Collection() {
+			c.emptyCollection(msg, f, dir)
+		}
+	}
+
+	g.P("return nil")
+	g.P("}")
+	g.P()
+}
+
+// emptyCollection records an empty collection in the empty collections
+// field of the message, or restores it from there.
+func (c *converter) emptyCollection(msg *Message, f *Field, dir string) {
+	g := c.g
+	list := "d." + msg.EmptyCollections
+
+	if dir == "from" {
+		g.P("if doc.", f.Name, " != nil && len(doc.", f.Name, ") == 0 {")
+		g.P(list, " = append(", list, ", ", quote(f.JSONName), ")")
+		g.P("}")
+
+		return
+	}
+
+	g.P("if len(doc.", f.Name, ") == 0 {")
+	g.P("doc.", f.Name, " = nil")
+	g.P()
+	g.P("if ", c.emptyHelper(), "(", list, ", ", quote(f.JSONName), ") {")
+	g.P("doc.", f.Name, " = ", c.emptyValueHelper(f), "()")
+	g.P("}")
+	g.P("}")
+}
+
+func (c *converter) emptyHelper() string {
+	return c.use("isEmptyCollection", func() {
+		g := c.g
+
+		g.P("func isEmptyCollection(empty []string, name string) bool {")
+		g.P("for i := range empty {")
+		g.P("if empty[i] == name {")
+		g.P("return true")
+		g.P("}")
+		g.P("}")
+		g.P()
+		g.P("return false")
+		g.P("}")
+		g.P()
+	})
+}
+
+// emptyValueHelper returns a helper that creates an empty collection
+// of the doc type of the field, the doc package can't be referred to
+// in the methods as the doc parameter shadows it.
+func (c *converter) emptyValueHelper(f *Field) string {
+	name := "emptyDoc" + pluralName(f.Elem.Name)
+	if f.Map {
+		name = "emptyDoc" + mapName(f.Key, f.Elem.Name)
+	}
+
+	return c.use(name, func() {
+		g := c.g
+		t := docFieldType(g, f)
+
+		g.P("func ", name, "() ", t, " {")
+		g.P("return make(", t, ", 0)")
+		g.P("}")
+		g.P()
+	})
+}
+
+// offsetHelpers returns the helper that gets the UTC offset of a
+// doc timestamp, or the one that converts a timestamp to a doc
+// timestamp in a UTC offset.
+func (c *converter) offsetHelpers(e *Elem, dir string) string {
+	if dir == "from" {
+		return c.use("docTimeOffset", func() {
+			g := c.g
+
+			g.P("func docTimeOffset(v *", docElemType(g, e), ") int32 {")
+			g.P("if v == nil {")
+			g.P("return 0")
+			g.P("}")
+			g.P()
+			g.P("_, offset := v.Zone()")
+			g.P()
+			g.P("return int32(offset)")
+			g.P("}")
+			g.P()
+		})
+	}
+
+	return c.use("toDocTimeInOffset", func() {
+		g := c.g
+		toDoc := c.elemHelper(e, dir)
+
+		g.P("func toDocTimeInOffset(v ", rpcElemType(g, e), ", offset int32) (*", docElemType(g, e), ", error) {")
+		g.P("t, err := ", toDoc, "(v)")
+		g.P("if err != nil || t == nil || offset == 0 {")
+		g.P("return t, err")
+		g.P("}")
+		g.P()
+		g.P("if offset <= -maxOffset || offset >= maxOffset {")
+		g.P(`return nil, `, fmtErrorf, `("UTC offset %d is out of range", offset)`)
+		g.P("}")
+		g.P()
+		g.P("local := t.In(", timeIdent("FixedZone"), `("", int(offset)))`)
+		g.P()
+		g.P("return &local, nil")
+		g.P("}")
+		g.P()
+		g.P("// maxOffset is the bound of UTC offsets in seconds, RFC 3339 offsets")
+		g.P("// are less than a day.")
+		g.P("const maxOffset = 24 * 60 * 60")
+		g.P()
+	})
+}
+
+func (c *converter) fieldHelper(f *Field, dir string) string {
+	switch {
+	case f.Map:
+		return c.mapHelper(f.Key, f.Elem, dir)
+	case f.Repeated:
+		return c.listHelper(f.Elem, dir)
+	}
+
+	return c.elemHelper(f.Elem, dir)
+}
+
+// byPointer is true if the elem helper takes and returns pointers.
+func byPointer(e *Elem) bool {
+	return e.Kind != KindEnum
+}
+
+// types returns the argument and result types of a conversion.
+func (c *converter) types(docType, rpcType string, dir string) (string, string) {
+	if dir == "to" {
+		return rpcType, docType
+	}
+
+	return docType, rpcType
+}
+
+func (c *converter) listHelper(e *Elem, dir string) string {
+	name := dir + "Doc" + pluralName(e.Name)
+
+	return c.use(name, func() {
+		g := c.g
+		elem := c.elemHelper(e, dir)
+		arg, res := c.types("[]"+docElemType(g, e), "[]"+rpcElemType(g, e), dir)
+
+		g.P("func ", name, "(s ", arg, ") (", res, ", error) {")
+		g.P("if s == nil {")
+		g.P("return nil, nil")
+		g.P("}")
+		g.P()
+		g.P("c := make(", res, ", len(s))")
+		g.P()
+		g.P("for i := range s {")
+
+		switch {
+		case !byPointer(e):
+			g.P("v, err := ", elem, "(s[i])")
+		case dir == "from":
+			g.P("v, err := ", elem, "(&s[i])")
+		default:
+			g.P("v, err := ", elem, "(s[i])")
+		}
+
+		g.P("if err != nil {")
+		g.P(`return nil, `, fmtErrorf, `("%d: %w", i, err)`)
+		g.P("}")
+		g.P()
+
+		if byPointer(e) && dir == "to" {
+			g.P("if v != nil {")
+			g.P("c[i] = *v")
+			g.P("}")
+		} else {
+			g.P("c[i] = v")
+		}
+
+		g.P("}")
+		g.P()
+		g.P("return c, nil")
+		g.P("}")
+		g.P()
+	})
+}
+
+func (c *converter) mapHelper(key *Elem, e *Elem, dir string) string {
+	name := dir + "Doc" + mapName(key, e.Name)
+
+	return c.use(name, func() {
+		g := c.g
+		elem := c.elemHelper(e, dir)
+		keyType := docElemType(g, key)
+		arg, res := c.types(
+			"map["+keyType+"]"+docElemType(g, e),
+			"map["+keyType+"]"+rpcElemType(g, e),
+			dir,
+		)
+
+		g.P("func ", name, "(m ", arg, ") (", res, ", error) {")
+		g.P("if m == nil {")
+		g.P("return nil, nil")
+		g.P("}")
+		g.P()
+		g.P("c := make(", res, ", len(m))")
+		g.P()
+		g.P("for k := range m {")
+
+		if byPointer(e) && dir == "from" {
+			g.P("e := m[k]")
+			g.P()
+			g.P("v, err := ", elem, "(&e)")
+		} else {
+			g.P("v, err := ", elem, "(m[k])")
+		}
+
+		g.P("if err != nil {")
+		g.P(`return nil, `, fmtErrorf, `("%v: %w", k, err)`)
+		g.P("}")
+		g.P()
+
+		if byPointer(e) && dir == "to" {
+			g.P("var e ", docElemType(g, e))
+			g.P()
+			g.P("if v != nil {")
+			g.P("e = *v")
+			g.P("}")
+			g.P()
+			g.P("c[k] = e")
+		} else {
+			g.P("c[k] = v")
+		}
+
+		g.P("}")
+		g.P()
+		g.P("return c, nil")
+		g.P("}")
+		g.P()
+	})
+}
+
+func (c *converter) elemHelper(e *Elem, dir string) string {
+	name := dir + "Doc" + e.Name
+
+	return c.use(name, func() {
+		g := c.g
+		docType, rpcType := docElemType(g, e), rpcElemType(g, e)
+
+		if byPointer(e) {
+			docType = "*" + docType
+		}
+
+		arg, res := c.types(docType, rpcType, dir)
+
+		g.P("func ", name, "(v ", arg, ") (", res, ", error) {")
+
+		switch e.Kind {
+		case KindEnum:
+			c.enumBody(e, dir)
+		case KindMessage:
+			c.messageBody(e, dir)
+		default:
+			c.wellKnownBody(e, dir)
+		}
+
+		g.P("}")
+		g.P()
+	})
+}
+
+func (c *converter) enumBody(e *Elem, dir string) {
+	g := c.g
+	docType := docElemType(g, e)
+	protoName := e.Enum.Desc.FullName()
+
+	if dir == "from" {
+		g.P(`if v == "" {`)
+		g.P("return 0, nil")
+		g.P("}")
+		g.P()
+		g.P("n, ok := ", e.RPC.GoName, "_value[string(v)]")
+		g.P("if !ok {")
+		g.P(`return 0, `, fmtErrorf, `("unknown `, protoName, ` value %q", v)`)
+		g.P("}")
+		g.P()
+		g.P("return ", e.RPC.GoName, "(n), nil")
+
+		return
+	}
+
+	g.P("if v == 0 {")
+	g.P(`return "", nil`)
+	g.P("}")
+	g.P()
+	g.P("name, ok := ", e.RPC.GoName, "_name[int32(v)]")
+	g.P("if !ok {")
+	g.P(`return "", `, fmtErrorf, `("unknown `, protoName, ` value %d", v)`)
+	g.P("}")
+	g.P()
+	g.P("return ", docType, "(name), nil")
+}
+
+func (c *converter) messageBody(e *Elem, dir string) {
+	g := c.g
+
+	g.P("if v == nil {")
+	g.P("return nil, nil")
+	g.P("}")
+	g.P()
+
+	if dir == "from" {
+		g.P("var m ", e.RPC.GoName)
+		g.P()
+		g.P("err := m.FromDoc", e.Name, "(v)")
+	} else {
+		g.P("var m ", docElemType(g, e))
+		g.P()
+		g.P("err := v.ToDoc", e.Name, "(&m)")
+	}
+
+	g.P("if err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P()
+	g.P("return &m, nil")
+}
+
+func (c *converter) wellKnownBody(e *Elem, dir string) {
+	g := c.g
+
+	g.P("if v == nil {")
+	g.P("return nil, nil")
+	g.P("}")
+	g.P()
+
+	switch {
+	case dir == "from" && e.Kind == KindWrapper:
+		constructor := e.RPC.GoImportPath.Ident(strings.TrimSuffix(e.RPC.GoName, "Value"))
+		g.P("return ", constructor, "(*v), nil")
+	case dir == "from" && e.Kind == KindTimestamp:
+		// Timestamps are limited to the years 1 to 9999.
+		g.P("t := ", e.RPC.GoImportPath.Ident("New"), "(*v)")
+		g.P()
+		g.P("err := t.CheckValid()")
+		g.P("if err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P()
+		g.P("return t, nil")
+	case dir == "from":
+		g.P("return ", e.RPC.GoImportPath.Ident("New"), "(*v), nil")
+	case e.Kind == KindTimestamp:
+		g.P("err := v.CheckValid()")
+		g.P("if err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P()
+		g.P("t := v.AsTime()")
+		g.P()
+		g.P("return &t, nil")
+	case e.Kind == KindDuration:
+		g.P("err := v.CheckValid()")
+		g.P("if err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P()
+		g.P("// AsDuration saturates durations that time.Duration can't hold.")
+		g.P("d := v.AsDuration()")
+		g.P()
+		g.P("if r := ", e.RPC.GoImportPath.Ident("New"), "(d); r.Seconds != v.Seconds || r.Nanos != v.Nanos {")
+		g.P(`return nil, `, fmtErrorf, `("duration of %ds is out of range", v.Seconds)`)
+		g.P("}")
+		g.P()
+		g.P("return &d, nil")
+	default:
+		g.P("value := v.GetValue()")
+		g.P()
+		g.P("return &value, nil")
+	}
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// copier writes the DeepCopy and Equal methods of the doc structs and
+// the helpers they use.
+type copier struct {
+	helpers
+
+	g *protogen.GeneratedFile
+	m *Model
+}
+
+const equalOptionsSource = `
+// EqualOption configures the Equal methods.
+type EqualOption func(o *equalOptions)
+
+type equalOptions struct {
+	ignore         map[string]bool
+	nilEqualsEmpty bool
+}
+
+func newEqualOptions(opts []EqualOption) *equalOptions {
+	o := equalOptions{ignore: make(map[string]bool)}
+	for i := range opts {
+		opts[i](&o)
+	}
+	return &o
+}
+
+func (o *equalOptions) ignored(structName, field string) bool {
+	return o.ignore[field] || o.ignore[structName+"."+field]
+}
+
+// IgnoreFields makes Equal skip the named fields. A field can be
+// named by itself, f.ex. "ID", to be ignored in all structs, or
+// qualified, f.ex. "Block.ID".
+func IgnoreFields(fields ...string) EqualOption {
+	return func(o *equalOptions) {
+		for _, f := range fields {
+			o.ignore[f] = true
+		}
+	}
+}
+
+// NilEqualsEmpty makes Equal treat nil and empty slices and maps as
+// equal. By default they are different, as they are for
+// reflect.DeepEqual.
+func NilEqualsEmpty() EqualOption {
+	return func(o *equalOptions) {
+		o.nilEqualsEmpty = true
+	}
+}
+`
+
+func generateDeepCopy(g *protogen.GeneratedFile, m *Model) {
+	c := copier{g: g, m: m}
+
+	g.P(generatedHeader)
+	g.P()
+	g.P("package ", packageName(m.DocPackage))
+	g.P(equalOptionsSource)
+
+	var (
+		timestamps []string
+		seen       = make(map[string]bool)
+	)
+
+	for _, msg := range m.Messages {
+		for _, f := range msg.Fields {
+			if f.Elem.Kind == KindTimestamp && !f.Repeated && !f.Map && !seen[f.Name] {
+				seen[f.Name] = true
+				timestamps = append(timestamps, quote(f.Name))
+			}
+		}
+	}
+
+	g.P("// IgnoreTimestamps makes Equal skip the timestamp fields.")
+	g.P("func IgnoreTimestamps() EqualOption {")
+	g.P("return IgnoreFields(", strings.Join(timestamps, ", "), ")")
+	g.P("}")
+	g.P()
+
+	for _, msg := range m.Messages {
+		c.deepCopy(msg)
+		c.equal(msg)
+	}
+
+	c.flush()
+}
+
+func (c *copier) deepCopy(msg *Message) {
+	g := c.g
+
+	g.P("// DeepCopy returns a copy of the ", msg.Name, " that doesn't share any")
+	g.P("// slices, maps or pointers with the original. Nil slices and maps")
+	g.P("// stay nil and empty ones stay empty.")
+	g.P("func (x *", msg.Name, ") DeepCopy() *", msg.Name, " {")
+	g.P("if x == nil {")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+	g.P("c := *x")
+
+	for _, f := range msg.Fields {
+		if expr := c.copyField(f); expr != "" {
+			g.P("c.", f.Name, " = ", expr)
+		}
+	}
+
+	g.P()
+	g.P("return &c")
+	g.P("}")
+	g.P()
+}
+
+func (c *copier) equal(msg *Message) {
+	g := c.g
+
+	g.P("// Equal reports whether the ", msg.Name, " is equal to other. Timestamps")
+	g.P("// are equal if they represent the same instant.")
+	g.P("func (x *", msg.Name, ") Equal(other *", msg.Name, ", opts ...EqualOption) bool {")
+	g.P("return x.equal(other, newEqualOptions(opts))")
+	g.P("}")
+	g.P()
+	g.P("func (x *", msg.Name, ") equal(y *", msg.Name, ", o *equalOptions) bool {")
+	g.P("if x == nil || y == nil {")
+	g.P("return x == y")
+	g.P("}")
+
+	for _, f := range msg.Fields {
+		g.P(`if !o.ignored("`, msg.Name, `", "`, f.Name, `") && `, c.differField(f), ` {`)
+		g.P("return false")
+		g.P("}")
+	}
+
+	g.P()
+	g.P("return true")
+	g.P("}")
+	g.P()
+}
+
+// copyField returns an expression that copies the field of x, or an
+// empty string if assigning it is enough.
+func (c *copier) copyField(f *Field) string {
+	switch {
+	case f.Map:
+		return c.mapHelpers(f.Key, f.Elem) + "(x." + f.Name + ")"
+	case f.Repeated:
+		return c.listHelpers(f.Elem) + "(x." + f.Name + ")"
+	case f.Pointer() && f.Elem.Kind == KindMessage:
+		return "x." + f.Name + ".DeepCopy()"
+	case f.Pointer():
+		return c.pointerHelpers(f.Elem) + "(x." + f.Name + ")"
+	case f.Elem.IsBytes():
+		return c.bytesHelpers() + "(x." + f.Name + ")"
+	}
+
+	return ""
+}
+
+// differField returns an expression that is true if the field differs
+// between x and y.
+func (c *copier) differField(f *Field) string {
+	switch {
+	case f.Map:
+		return c.equalCall(c.mapHelpers(f.Key, f.Elem), f.Name)
+	case f.Repeated:
+		return c.equalCall(c.listHelpers(f.Elem), f.Name)
+	case f.Pointer() && f.Elem.Kind == KindMessage:
+		return fmt.Sprintf("!x.%[1]s.equal(y.%[1]s, o)", f.Name)
+	case f.Pointer():
+		return c.equalCall(c.pointerHelpers(f.Elem), f.Name)
+	case f.Elem.IsBytes():
+		return c.equalCall(c.bytesHelpers(), f.Name)
+	}
+
+	return fmt.Sprintf("x.%[1]s != y.%[1]s", f.Name)
+}
+
+// equalCall calls the equal helper that goes with a copy helper.
+func (c *copier) equalCall(copyHelper string, field string) string {
+	return fmt.Sprintf("!equal%[1]s(x.%[2]s, y.%[2]s, o)",
+		strings.TrimPrefix(copyHelper, "copy"), field)
+}
+
+// copyValue returns an expression that copies the addressable value v.
+func (c *copier) copyValue(e *Elem, v string) string {
+	switch {
+	case e.Kind == KindMessage:
+		return "*" + v + ".DeepCopy()"
+	case e.IsBytes():
+		return c.bytesHelpers() + "(" + v + ")"
+	}
+
+	return v
+}
+
+// sameValue returns an expression that is true if the values a and b
+// are equal, message values must be addressable.
+func (c *copier) sameValue(e *Elem, a, b string) string {
+	if strings.HasPrefix(a, "*") && (e.Kind == KindTimestamp || e.Kind == KindMessage) {
+		a = "(" + a + ")"
+	}
+
+	switch {
+	case e.Kind == KindMessage:
+		return a + ".equal(&" + b + ", o)"
+	case e.Kind == KindTimestamp:
+		return a + ".Equal(" + b + ")"
+	case e.IsBytes():
+		c.bytesHelpers()
+
+		return "equalBytes(" + a + ", " + b + ", o)"
+	}
+
+	return a + " == " + b
+}
+
+// differValue is the negation of sameValue.
+func (c *copier) differValue(e *Elem, a, b string) string {
+	same := c.sameValue(e, a, b)
+
+	if strings.Contains(same, " == ") {
+		return strings.Replace(same, " == ", " != ", 1)
+	}
+
+	return "!" + same
+}
+
+func (c *copier) bytesHelpers() string {
+	return c.use("copyBytes", func() {
+		g := c.g
+
+		g.P("func copyBytes(b []byte) []byte {")
+		g.P("if b == nil {")
+		g.P("return nil")
+		g.P("}")
+		g.P()
+		g.P("c := make([]byte, len(b))")
+		g.P("copy(c, b)")
+		g.P()
+		g.P("return c")
+		g.P("}")
+		g.P()
+		g.P("func equalBytes(a, b []byte, o *equalOptions) bool {")
+		g.P("if !o.nilEqualsEmpty && (a == nil) != (b == nil) {")
+		g.P("return false")
+		g.P("}")
+		g.P()
+		g.P("return ", bytesEqual, "(a, b)")
+		g.P("}")
+		g.P()
+	})
+}
+
+func (c *copier) pointerHelpers(e *Elem) string {
+	name := docElemName(e)
+	if e.Kind == KindScalar || e.Kind == KindWrapper {
+		name += "Ptr"
+	}
+
+	return c.use("copy"+name, func() {
+		g := c.g
+		t := ident(g, e.Doc)
+
+		g.P("func copy", name, "(p *", t, ") *", t, " {")
+		g.P("if p == nil {")
+		g.P("return nil")
+		g.P("}")
+		g.P()
+		g.P("c := ", c.copyValue(e, "*p"))
+		g.P()
+		g.P("return &c")
+		g.P("}")
+		g.P()
+		g.P("func equal", name, "(a, b *", t, ", o *equalOptions) bool {")
+		g.P("if a == nil || b == nil {")
+		g.P("return a == b")
+		g.P("}")
+		g.P()
+		g.P("return ", c.sameValue(e, "*a", "*b"))
+		g.P("}")
+		g.P()
+	})
+}
+
+func (c *copier) listHelpers(e *Elem) string {
+	name := pluralName(docElemName(e))
+
+	return c.use("copy"+name, func() {
+		g := c.g
+		t := "[]" + ident(g, e.Doc)
+
+		g.P("func copy", name, "(s ", t, ") ", t, " {")
+		g.P("if s == nil {")
+		g.P("return nil")
+		g.P("}")
+		g.P()
+		g.P("c := make(", t, ", len(s))")
+
+		if e.Plain() {
+			g.P("copy(c, s)")
+		} else {
+			g.P()
+			g.P("for i := range s {")
+			g.P("c[i] = ", c.copyValue(e, "s[i]"))
+			g.P("}")
+		}
+
+		g.P()
+		g.P("return c")
+		g.P("}")
+		g.P()
+		g.P("func equal", name, "(a, b ", t, ", o *equalOptions) bool {")
+		g.P("if len(a) != len(b) || (!o.nilEqualsEmpty && (a == nil) != (b == nil)) {")
+		g.P("return false")
+		g.P("}")
+		g.P()
+		g.P("for i := range a {")
+		g.P("if ", c.differValue(e, "a[i]", "b[i]"), " {")
+		g.P("return false")
+		g.P("}")
+		g.P("}")
+		g.P()
+		g.P("return true")
+		g.P("}")
+		g.P()
+	})
+}
+
+func (c *copier) mapHelpers(key *Elem, e *Elem) string {
+	name := mapName(key, docElemName(e))
+
+	return c.use("copy"+name, func() {
+		g := c.g
+		t := "map[" + ident(g, key.Doc) + "]" + ident(g, e.Doc)
+
+		g.P("func copy", name, "(m ", t, ") ", t, " {")
+		g.P("if m == nil {")
+		g.P("return nil")
+		g.P("}")
+		g.P()
+		g.P("c := make(", t, ", len(m))")
+		g.P()
+		g.P("for k, v := range m {")
+		g.P("c[k] = ", c.copyValue(e, "v"))
+		g.P("}")
+		g.P()
+		g.P("return c")
+		g.P("}")
+		g.P()
+		g.P("func equal", name, "(a, b ", t, ", o *equalOptions) bool {")
+		g.P("if len(a) != len(b) || (!o.nilEqualsEmpty && (a == nil) != (b == nil)) {")
+		g.P("return false")
+		g.P("}")
+		g.P()
+		g.P("for k, v := range a {")
+		g.P("w, ok := b[k]")
+		g.P("if !ok || ", c.differValue(e, "v", "w"), " {")
+		g.P("return false")
+		g.P("}")
+		g.P("}")
+		g.P()
+		g.P("return true")
+		g.P("}")
+		g.P()
+	})
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateDocument writes the doc package types. Enums become string
+// types and messages become structs with JSON tags.
+func generateDocument(g *protogen.GeneratedFile, m *Model) {
+	g.P(generatedHeader)
+	g.P()
+	g.P("package ", packageName(m.DocPackage))
+	g.P()
+
+	for _, e := range m.Enums {
+		writeComments(g, e.Comments, false)
+		g.P("type ", e.Name, " string")
+		g.P()
+		g.P("const (")
+
+		for _, v := range e.Values {
+			writeComments(g, v.Comments, v.Deprecated)
+			g.P(v.Name, " ", e.Name, " = ", quote(v.Value))
+		}
+
+		g.P(")")
+		g.P()
+	}
+
+	for _, msg := range m.Messages {
+		writeComments(g, msg.Comments, msg.Deprecated)
+		g.P("type ", msg.Name, " struct {")
+
+		for _, f := range msg.Fields {
+			writeComments(g, f.Comments, f.Deprecated)
+			g.P(f.Name, " ", docFieldType(g, f), " `json:\"", f.JSONName, ",omitempty\"`")
+		}
+
+		g.P("}")
+		g.P()
+	}
+}
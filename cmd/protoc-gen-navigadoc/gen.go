@@ -0,0 +1,163 @@
+package main
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const generatedHeader = "// Code generated by protoc-gen-navigadoc. DO NOT EDIT."
+
+var (
+	fmtErrorf  = protogen.GoImportPath("fmt").Ident("Errorf")
+	bytesEqual = protogen.GoImportPath("bytes").Ident("Equal")
+)
+
+// helpers collects the helper functions a file needs. Every helper is
+// written once, after the rest of the file.
+type helpers struct {
+	seen  map[string]bool
+	queue []func()
+}
+
+// use returns the name of the helper, and queues it for writing if it
+// hasn't been used before.
+func (h *helpers) use(name string, write func()) string {
+	if h.seen == nil {
+		h.seen = make(map[string]bool)
+	}
+
+	if !h.seen[name] {
+		h.seen[name] = true
+		h.queue = append(h.queue, write)
+	}
+
+	return name
+}
+
+// flush writes the queued helpers, including the ones that they use
+// in turn.
+func (h *helpers) flush() {
+	for len(h.queue) > 0 {
+		write := h.queue[0]
+		h.queue = h.queue[1:]
+
+		write()
+	}
+}
+
+// ident returns the qualified name of a Go type, predeclared types
+// have no import path.
+func ident(g *protogen.GeneratedFile, id protogen.GoIdent) string {
+	if id.GoImportPath == "" {
+		return id.GoName
+	}
+
+	return g.QualifiedGoIdent(id)
+}
+
+// docElemType is the Go type of a value in the doc package.
+func docElemType(g *protogen.GeneratedFile, e *Elem) string {
+	return ident(g, e.Doc)
+}
+
+// rpcElemType is the Go type of a value in the rpc package.
+func rpcElemType(g *protogen.GeneratedFile, e *Elem) string {
+	switch e.Kind {
+	case KindMessage, KindTimestamp, KindDuration, KindWrapper:
+		return "*" + ident(g, e.RPC)
+	}
+
+	return ident(g, e.RPC)
+}
+
+// docFieldType is the Go type of a field in the doc package.
+func docFieldType(g *protogen.GeneratedFile, f *Field) string {
+	switch {
+	case f.Map:
+		return "map[" + docElemType(g, f.Key) + "]" + docElemType(g, f.Elem)
+	case f.Repeated:
+		return "[]" + docElemType(g, f.Elem)
+	case f.Pointer():
+		return "*" + docElemType(g, f.Elem)
+	}
+
+	return docElemType(g, f.Elem)
+}
+
+// rpcFieldType is the Go type of a field in the rpc package.
+func rpcFieldType(g *protogen.GeneratedFile, f *Field) string {
+	switch {
+	case f.Map:
+		return "map[" + rpcElemType(g, f.Key) + "]" + rpcElemType(g, f.Elem)
+	case f.Repeated:
+		return "[]" + rpcElemType(g, f.Elem)
+	case f.Optional:
+		return "*" + rpcElemType(g, f.Elem)
+	}
+
+	return rpcElemType(g, f.Elem)
+}
+
+// docElemName names helpers that work on doc values, values that have
+// the same Go type share helpers.
+func docElemName(e *Elem) string {
+	switch e.Kind {
+	case KindMessage, KindEnum, KindTimestamp, KindDuration:
+		return e.Name
+	}
+
+	if e.IsBytes() {
+		return "Bytes"
+	}
+
+	return strings.Title(strings.TrimPrefix(e.Doc.GoName, "[]"))
+}
+
+// mapName names helpers for maps, string keys are implied.
+func mapName(key *Elem, name string) string {
+	if key.Scalar == protoreflect.StringKind {
+		return name + "Map"
+	}
+
+	return docElemName(key) + name + "Map"
+}
+
+func pluralName(name string) string {
+	switch {
+	case strings.HasSuffix(name, "s"):
+		return name + "List"
+	case strings.HasSuffix(name, "y"):
+		return strings.TrimSuffix(name, "y") + "ies"
+	}
+
+	return name + "s"
+}
+
+func writeComments(g *protogen.GeneratedFile, comments protogen.Comments, deprecated bool) {
+	c := strings.TrimSuffix(comments.String(), "\n")
+
+	if deprecated {
+		if c != "" {
+			c += "\n//\n"
+		}
+
+		c += "// Deprecated: Do not use."
+	}
+
+	if c != "" {
+		g.P(c)
+	}
+}
+
+// packageName is the name of the package with the given import path.
+func packageName(importPath protogen.GoImportPath) string {
+	return path.Base(string(importPath))
+}
+
+func quote(s string) string {
+	return strconv.Quote(s)
+}
@@ -0,0 +1,126 @@
+// Command protoc-gen-navigadoc is a protoc plugin that generates the doc
+// package structs, the conversions between them and the protobuf
+// messages, the NavigaDoc JSON schema and TypeScript declarations for
+// the JSON. It's run by "make generate":
+//
+//	protoc -I . \
+//		--go_out=. --go_opt=paths=source_relative \
+//		--navigadoc_out=. --navigadoc_opt=paths=source_relative \
+//		rpc/document.proto
+//
+// The doc package, schema and TypeScript locations default to "doc",
+// "schema/navigadoc-schema.json" and "typescript/navigadoc.d.ts" next to
+// the directory of the proto file, and can be changed with the doc_dir,
+// doc_package, schema and typescript parameters. The $id of the schema
+// is set with schema_id.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+type params struct {
+	docDir     string
+	docPackage string
+	schema     string
+	schemaID   string
+	schemaRoot string
+	typeScript string
+}
+
+func main() {
+	var (
+		flags flag.FlagSet
+		p     params
+	)
+
+	flags.StringVar(&p.docDir, "doc_dir", "", "output directory of the doc package")
+	flags.StringVar(&p.docPackage, "doc_package", "", "import path of the doc package")
+	flags.StringVar(&p.schema, "schema", "", "output path of the JSON schema")
+	flags.StringVar(&p.schemaID, "schema_id", "", "$id of the JSON schema")
+	flags.StringVar(&p.schemaRoot, "schema_root", "Document", "message that is the root of the JSON schema")
+	flags.StringVar(&p.typeScript, "typescript", "", "output path of the TypeScript declarations")
+
+	protogen.Options{
+		ParamFunc: flags.Set,
+	}.Run(func(gen *protogen.Plugin) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+
+		return generate(gen, p)
+	})
+}
+
+func generate(gen *protogen.Plugin, p params) error {
+	var files []*protogen.File
+
+	for _, f := range gen.Files {
+		if f.Generate {
+			files = append(files, f)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	rpcDir := path.Dir(files[0].GeneratedFilenamePrefix)
+
+	if p.docDir == "" {
+		p.docDir = path.Join(path.Dir(rpcDir), "doc")
+	}
+
+	if p.docPackage == "" {
+		p.docPackage = path.Join(path.Dir(string(files[0].GoImportPath)), "doc")
+	}
+
+	if p.schema == "" {
+		p.schema = path.Join(path.Dir(rpcDir), "schema", "navigadoc-schema.json")
+	}
+
+	if p.typeScript == "" {
+		p.typeScript = path.Join(path.Dir(rpcDir), "typescript", "navigadoc.d.ts")
+	}
+
+	if p.schemaID == "" {
+		p.schemaID = "https://" + path.Join(path.Dir(p.docPackage), p.schema)
+	}
+
+	options, err := newOptionReader(gen.Files)
+	if err != nil {
+		return err
+	}
+
+	model, err := buildModel(files, protogen.GoImportPath(p.docPackage), options)
+	if err != nil {
+		return err
+	}
+
+	generateDocument(
+		gen.NewGeneratedFile(path.Join(p.docDir, "document.go"), model.DocPackage),
+		model,
+	)
+
+	generateDeepCopy(
+		gen.NewGeneratedFile(path.Join(p.docDir, "deepcopy.go"), model.DocPackage),
+		model,
+	)
+
+	generateConversion(
+		gen.NewGeneratedFile(path.Join(rpcDir, "conversion.go"), model.RPCPackage),
+		model, files[0].GoPackageName,
+	)
+
+	generateTypeScript(gen.NewGeneratedFile(p.typeScript, ""), model)
+
+	err = generateSchema(gen.NewGeneratedFile(p.schema, ""), model, p.schemaID, p.schemaRoot)
+	if err != nil {
+		return fmt.Errorf("failed to generate JSON schema: %w", err)
+	}
+
+	return nil
+}
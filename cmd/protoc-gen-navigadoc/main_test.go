@@ -0,0 +1,555 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/navigacontentlab/navigadoc/rpc"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+	}
+
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+
+	return &f
+}
+
+func repeated(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
+
+const (
+	typeString  = descriptorpb.FieldDescriptorProto_TYPE_STRING
+	typeInt32   = descriptorpb.FieldDescriptorProto_TYPE_INT32
+	typeInt64   = descriptorpb.FieldDescriptorProto_TYPE_INT64
+	typeBytes   = descriptorpb.FieldDescriptorProto_TYPE_BYTES
+	typeEnum    = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+	typeMessage = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+)
+
+// testFile is a proto file that uses the features that document.proto
+// doesn't.
+func testFile() *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("rpc/test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		Dependency: []string{
+			"google/protobuf/timestamp.proto",
+			"google/protobuf/duration.proto",
+			"google/protobuf/wrappers.proto",
+		},
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("example.com/test/rpc"),
+		},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Format"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("FORMAT_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("FORMAT_NAVIGADOC"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Document"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("uuid", 1, typeString, ""),
+					field("format", 2, typeEnum, ".test.Format"),
+					repeated(field("formats", 3, typeEnum, ".test.Format")),
+					repeated(field("tags", 4, typeString, "")),
+					field("section", 5, typeMessage, ".test.Document.Section"),
+					repeated(field("sections", 6, typeMessage, ".test.Document.Section")),
+					field("created", 7, typeMessage, ".google.protobuf.Timestamp"),
+					field("ttl", 8, typeMessage, ".google.protobuf.Duration"),
+					field("label", 9, typeMessage, ".google.protobuf.StringValue"),
+					repeated(field("sections_by_id", 10, typeMessage, ".test.Document.SectionsByIdEntry")),
+					field("checksum", 11, typeBytes, ""),
+					repeated(field("times", 12, typeMessage, ".google.protobuf.Timestamp")),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("Section"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							field("parent_id", 1, typeString, ""),
+							field("weight", 2, typeInt64, ""),
+							field("kind", 3, typeEnum, ".test.Document.Section.Kind"),
+						},
+						EnumType: []*descriptorpb.EnumDescriptorProto{{
+							Name: proto.String("Kind"),
+							Value: []*descriptorpb.EnumValueDescriptorProto{
+								{Name: proto.String("KIND_UNSPECIFIED"), Number: proto.Int32(0)},
+								{Name: proto.String("KIND_MAIN"), Number: proto.Int32(1)},
+							},
+						}},
+					},
+					{
+						Name: proto.String("SectionsByIdEntry"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							field("key", 1, typeString, ""),
+							field("value", 2, typeMessage, ".test.Document.Section"),
+						},
+						Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+					},
+				},
+			},
+		},
+	}
+}
+
+func runGenerator(t *testing.T, file *descriptorpb.FileDescriptorProto) (map[string]string, error) {
+	t.Helper()
+
+	req := pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{file.GetName()},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+			protodesc.ToFileDescriptorProto(durationpb.File_google_protobuf_duration_proto),
+			protodesc.ToFileDescriptorProto(wrapperspb.File_google_protobuf_wrappers_proto),
+			protodesc.ToFileDescriptorProto(emptypb.File_google_protobuf_empty_proto),
+			protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+			protodesc.ToFileDescriptorProto(rpc.File_rpc_options_proto),
+			file,
+		},
+	}
+
+	gen, err := protogen.Options{}.New(&req)
+	if err != nil {
+		t.Fatalf("failed to create plugin: %v", err)
+	}
+
+	err = generate(gen, params{schemaRoot: "Document"})
+	if err != nil {
+		return nil, err
+	}
+
+	res := gen.Response()
+	if res.Error != nil {
+		t.Fatalf("failed to generate files: %s", res.GetError())
+	}
+
+	files := make(map[string]string)
+
+	for _, f := range res.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	return files, nil
+}
+
+func TestGenerate(t *testing.T) {
+	files, err := runGenerator(t, testFile())
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	for _, name := range []string{
+		"doc/document.go", "doc/deepcopy.go", "rpc/conversion.go",
+		"schema/navigadoc-schema.json", "typescript/navigadoc.d.ts",
+	} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("%s wasn't generated", name)
+		}
+	}
+
+	// Collapse the gofmt alignment.
+	document := strings.Join(strings.Fields(files["doc/document.go"]), " ")
+
+	for _, want := range []string{
+		"type Format string",
+		`FormatUnspecified Format = ""`,
+		`FormatNavigadoc Format = "FORMAT_NAVIGADOC"`,
+		`DocumentSectionKindMain DocumentSectionKind = "KIND_MAIN"`,
+		"type DocumentSection struct",
+		"ParentID string",
+		"Formats []Format",
+		"Tags []string",
+		"Section *DocumentSection",
+		"Sections []DocumentSection",
+		"SectionsByID map[string]DocumentSection",
+		"Created *time.Time",
+		"Ttl *time.Duration",
+		"Label *string",
+		"Checksum []byte",
+		"Times []time.Time",
+	} {
+		if !strings.Contains(document, want) {
+			t.Errorf("expected doc/document.go to contain %q", want)
+		}
+	}
+
+	conversion := files["rpc/conversion.go"]
+
+	for _, want := range []string{
+		"func (d *Document_Section) FromDocDocumentSection(",
+		"func fromDocFormat(v doc.Format) (Format, error)",
+		"func toDocDocumentSectionMap(m map[string]*Document_Section) (map[string]doc.DocumentSection, error)",
+		"func fromDocStringValue(v *string) (*wrapperspb.StringValue, error)",
+		"func toDocDuration(v *durationpb.Duration) (*time.Duration, error)",
+		"func toDocTimes(s []*timestamppb.Timestamp) ([]time.Time, error)",
+	} {
+		if !strings.Contains(conversion, want) {
+			t.Errorf("expected rpc/conversion.go to contain %q", want)
+		}
+	}
+
+	typeScript := files["typescript/navigadoc.d.ts"]
+
+	for _, want := range []string{
+		"export type Timestamp = string;",
+		`export type Format = "FORMAT_NAVIGADOC";`,
+		"export interface DocumentSection {",
+		"  parent_id?: string;",
+		"  formats?: Format[];",
+		"  section?: DocumentSection;",
+		"  created?: Timestamp;",
+		"  ttl?: number;",
+		"  sections_by_id?: { [key: string]: DocumentSection };",
+		"  checksum?: string;",
+		"  times?: Timestamp[];",
+	} {
+		if !strings.Contains(typeScript, want) {
+			t.Errorf("expected typescript/navigadoc.d.ts to contain %q", want)
+		}
+	}
+
+	// The doc package only depends on the standard library, so it can
+	// be type checked here.
+	fset := token.NewFileSet()
+
+	var parsed []*ast.File
+
+	for _, name := range []string{"doc/document.go", "doc/deepcopy.go"} {
+		f, err := parser.ParseFile(fset, name, files[name], 0)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", name, err)
+		}
+
+		parsed = append(parsed, f)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	_, err = conf.Check("example.com/test/doc", fset, parsed, nil)
+	if err != nil {
+		t.Fatalf("generated doc package doesn't compile: %v", err)
+	}
+}
+
+func TestGenerateSchemaOptions(t *testing.T) {
+	file := testFile()
+	file.Dependency = append(file.Dependency, "rpc/options.proto")
+
+	msg := file.MessageType[0]
+	msg.Options = &descriptorpb.MessageOptions{}
+	proto.SetExtension(msg.Options, rpc.E_MessageSchema, &rpc.MessageSchema{
+		AdditionalProperties: proto.Bool(false),
+	})
+
+	uuid := msg.Field[0]
+	uuid.Options = &descriptorpb.FieldOptions{}
+	proto.SetExtension(uuid.Options, rpc.E_FieldSchema, &rpc.FieldSchema{
+		Required: true,
+		Pattern:  "^[0-9a-f-]{36}$",
+	})
+
+	tags := msg.Field[3]
+	tags.Options = &descriptorpb.FieldOptions{Deprecated: proto.Bool(true)}
+	proto.SetExtension(tags.Options, rpc.E_FieldSchema, &rpc.FieldSchema{
+		Enum: []string{"a", "b"},
+	})
+
+	file.SourceCodeInfo = &descriptorpb.SourceCodeInfo{
+		Location: []*descriptorpb.SourceCodeInfo_Location{{
+			Path:            []int32{4, 0, 2, 0},
+			Span:            []int32{1, 0, 10},
+			LeadingComments: proto.String(" UUID identifies the\n document.\n\n Second paragraph.\n"),
+		}, {
+			Path:            []int32{4, 0, 2, 4},
+			Span:            []int32{2, 0, 10},
+			LeadingComments: proto.String(" Section is the main section.\n"),
+		}},
+	}
+
+	files, err := runGenerator(t, file)
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	typeScript := files["typescript/navigadoc.d.ts"]
+
+	for _, want := range []string{
+		"   * UUID identifies the\n   * document.\n   *\n   * Second paragraph.\n   */\n  uuid: string;",
+		"  /** @deprecated */\n  tags?: string[];",
+	} {
+		if !strings.Contains(typeScript, want) {
+			t.Errorf("expected typescript/navigadoc.d.ts to contain %q", want)
+		}
+	}
+
+	var schema struct {
+		Schema               string                     `json:"$schema"`
+		Required             []string                   `json:"required"`
+		AdditionalProperties *bool                      `json:"additionalProperties"`
+		Properties           map[string]json.RawMessage `json:"properties"`
+		Definitions          map[string]json.RawMessage `json:"definitions"`
+	}
+
+	err = json.Unmarshal([]byte(files["schema/navigadoc-schema.json"]), &schema)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	if schema.Schema != draft07 {
+		t.Errorf("expected the draft-07 dialect, got %q", schema.Schema)
+	}
+
+	if len(schema.Required) != 1 || schema.Required[0] != "uuid" {
+		t.Errorf("expected uuid to be required, got %v", schema.Required)
+	}
+
+	if schema.AdditionalProperties == nil || *schema.AdditionalProperties {
+		t.Error("expected additional properties to be disallowed")
+	}
+
+	if _, ok := schema.Definitions["documentSection"]; !ok {
+		t.Error("expected a definition for Document.Section")
+	}
+
+	for name, want := range map[string]string{
+		"uuid": `{"description":"UUID identifies the document.\n\nSecond paragraph.",` +
+			`"pattern":"^[0-9a-f-]{36}$","type":"string"}`,
+		"tags":   `{"deprecated":true,"items":{"enum":["a","b"],"type":"string"},"type":"array"}`,
+		"format": `{"enum":["FORMAT_NAVIGADOC"],"type":"string"}`,
+		"section": `{"allOf":[{"$ref":"#/definitions/documentSection"}],` +
+			`"description":"Section is the main section."}`,
+		"checksum": `{"contentEncoding":"base64","type":"string"}`,
+	} {
+		var got interface{}
+
+		err := json.Unmarshal(schema.Properties[name], &got)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", name, err)
+		}
+
+		compact, _ := json.Marshal(got)
+
+		if string(compact) != want {
+			t.Errorf("expected %s to be %s, got %s", name, want, compact)
+		}
+	}
+}
+
+// withConversion adds conversion companion fields to the test file, the
+// offset field holds the offset of the named field.
+func withConversion(offsetOf string) *descriptorpb.FileDescriptorProto {
+	file := testFile()
+	file.Dependency = append(file.Dependency, "rpc/options.proto")
+
+	offset := field("created_offset", 13, typeInt32, "")
+	offset.Options = &descriptorpb.FieldOptions{}
+	proto.SetExtension(offset.Options, rpc.E_Conversion, &rpc.FieldConversion{
+		OffsetOf: offsetOf,
+	})
+
+	empty := repeated(field("empty_collections", 14, typeString, ""))
+	empty.Options = &descriptorpb.FieldOptions{}
+	proto.SetExtension(empty.Options, rpc.E_Conversion, &rpc.FieldConversion{
+		EmptyCollections: true,
+	})
+
+	msg := file.MessageType[0]
+	msg.Field = append(msg.Field, offset, empty)
+
+	return file
+}
+
+func TestGenerateConversionOptions(t *testing.T) {
+	files, err := runGenerator(t, withConversion("created"))
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	conversion := files["rpc/conversion.go"]
+
+	for _, want := range []string{
+		"d.CreatedOffset = docTimeOffset(doc.Created)",
+		"doc.Created, err = toDocTimeInOffset(d.Created, d.CreatedOffset)",
+		`d.EmptyCollections = append(d.EmptyCollections, "sections_by_id")`,
+		`if isEmptyCollection(d.EmptyCollections, "tags") {`,
+		"doc.SectionsByID = emptyDocDocumentSectionMap()",
+		"func emptyDocFormats() []doc.Format {",
+	} {
+		if !strings.Contains(conversion, want) {
+			t.Errorf("expected rpc/conversion.go to contain %q", want)
+		}
+	}
+
+	// The companion fields only exist in the rpc package.
+	for _, name := range []string{
+		"doc/document.go", "schema/navigadoc-schema.json", "typescript/navigadoc.d.ts",
+	} {
+		for _, companion := range []string{"CreatedOffset", "created_offset", "EmptyCollections", "empty_collections"} {
+			if strings.Contains(files[name], companion) {
+				t.Errorf("expected %s not to contain %q", name, companion)
+			}
+		}
+	}
+
+	_, err = runGenerator(t, withConversion("ttl"))
+	if err == nil || !strings.Contains(err.Error(), "offset_of must name a timestamp field") {
+		t.Errorf("expected an error for an offset of a duration, got: %v", err)
+	}
+}
+
+func TestGenerateUnsupported(t *testing.T) {
+	oneof := testFile()
+	msg := oneof.MessageType[0]
+	msg.OneofDecl = []*descriptorpb.OneofDescriptorProto{{Name: proto.String("choice")}}
+	msg.Field[0].OneofIndex = proto.Int32(0)
+
+	unknown := testFile()
+	unknown.Dependency = append(unknown.Dependency, "google/protobuf/empty.proto")
+	unknown.MessageType[0].Field = append(unknown.MessageType[0].Field,
+		field("nothing", 20, typeMessage, ".google.protobuf.Empty"))
+
+	cases := map[string]struct {
+		file *descriptorpb.FileDescriptorProto
+		want string
+	}{
+		"oneof":   {oneof, "oneof fields are not supported"},
+		"unknown": {unknown, "message type google.protobuf.Empty is not supported"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := runGenerator(t, c.file)
+			if err == nil {
+				t.Fatal("expected generation to fail")
+			}
+
+			if !strings.Contains(err.Error(), c.want) {
+				t.Fatalf("expected error to contain %q, got %q", c.want, err.Error())
+			}
+		})
+	}
+}
+
+// TestGeneratedFilesUpToDate compiles rpc/document.proto and checks that
+// the checked in generated files match, so that changes to the proto
+// file or the generator aren't committed without running
+// "make generate".
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			ImportPaths: []string{"../.."},
+		}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+
+	compiled, err := compiler.Compile(context.Background(), "rpc/document.proto")
+	if err != nil {
+		t.Fatalf("failed to compile rpc/document.proto: %v", err)
+	}
+
+	req := pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"rpc/document.proto"},
+		Parameter:      proto.String("paths=source_relative"),
+	}
+
+	// The request lists the files in dependency order.
+	seen := make(map[string]bool)
+
+	var add func(f protoreflect.FileDescriptor)
+
+	add = func(f protoreflect.FileDescriptor) {
+		if seen[f.Path()] {
+			return
+		}
+
+		seen[f.Path()] = true
+
+		imports := f.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+
+		req.ProtoFile = append(req.ProtoFile, protodesc.ToFileDescriptorProto(f))
+	}
+
+	for _, f := range compiled {
+		add(f)
+	}
+
+	gen, err := protogen.Options{}.New(&req)
+	if err != nil {
+		t.Fatalf("failed to create plugin: %v", err)
+	}
+
+	err = generate(gen, params{schemaRoot: "Document"})
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	res := gen.Response()
+	if res.Error != nil {
+		t.Fatalf("failed to generate files: %s", res.GetError())
+	}
+
+	files := make(map[string]string)
+
+	for _, f := range res.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	for _, name := range []string{
+		"doc/document.go", "doc/deepcopy.go", "rpc/conversion.go",
+		"schema/navigadoc-schema.json", "typescript/navigadoc.d.ts",
+	} {
+		generated, ok := files[name]
+		if !ok {
+			t.Errorf("%s wasn't generated", name)
+
+			continue
+		}
+
+		current, err := ioutil.ReadFile(filepath.Join("../..", name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+
+		if generated != string(current) {
+			t.Errorf("%s is out of date, run \"make generate\"", name)
+		}
+	}
+}
@@ -0,0 +1,561 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Kind describes how a value is represented in the doc package and
+// how it's converted to and from its protobuf counterpart.
+type Kind int
+
+const (
+	// KindScalar values have the same Go type in both packages.
+	KindScalar Kind = iota
+	// KindEnum values are strings in the doc package.
+	KindEnum
+	// KindMessage values are doc structs.
+	KindMessage
+	// KindTimestamp values are time.Time in the doc package.
+	KindTimestamp
+	// KindDuration values are time.Duration in the doc package.
+	KindDuration
+	// KindWrapper values are the wrapped scalar in the doc package.
+	KindWrapper
+)
+
+// Elem is the type of a singular value, a list element, or a map key or
+// value.
+type Elem struct {
+	Kind Kind
+	// Name is used when naming helper functions, f.ex. "Block" for
+	// copyBlock and "Time" for fromDocTime.
+	Name string
+	// Doc is the Go type of the value in the doc package.
+	Doc protogen.GoIdent
+	// RPC is the Go type of the value in the rpc package, message
+	// values are always pointers to it.
+	RPC protogen.GoIdent
+	// Scalar is the kind of the (wrapped) scalar value.
+	Scalar protoreflect.Kind
+
+	Message *Message
+	Enum    *Enum
+}
+
+// IsBytes is true for []byte values, which need copying.
+func (e *Elem) IsBytes() bool {
+	return (e.Kind == KindScalar || e.Kind == KindWrapper) && e.Scalar == protoreflect.BytesKind
+}
+
+// Plain is true if assigning the doc value is a deep copy.
+func (e *Elem) Plain() bool {
+	return e.Kind != KindMessage && !e.IsBytes()
+}
+
+// Field is a field in a generated struct.
+type Field struct {
+	// Name is the Go name in the doc package.
+	Name string
+	// RPCName is the Go name in the rpc package.
+	RPCName string
+	// JSONName is the name used in the JSON representation.
+	JSONName   string
+	Comments   protogen.Comments
+	Deprecated bool
+
+	Repeated bool
+	Map      bool
+	// Optional is set for proto3 optional scalars, they're pointers in
+	// both packages.
+	Optional bool
+	// Key is the map key type.
+	Key  *Elem
+	Elem *Elem
+	// Schema is the naviga.field_schema option.
+	Schema FieldSchema
+	// OffsetRPCName is the Go name of the rpc field that holds the UTC
+	// offset of a timestamp, if any.
+	OffsetRPCName string
+
+	Desc protoreflect.FieldDescriptor
+}
+
+// Direct is true if the doc and rpc fields have the same type and
+// can be assigned to each other.
+func (f *Field) Direct() bool {
+	return f.Elem.Kind == KindScalar
+}
+
+// Collection is true for repeated and map fields.
+func (f *Field) Collection() bool {
+	return f.Repeated || f.Map
+}
+
+// Pointer is true for singular fields that are pointers in the doc
+// package.
+func (f *Field) Pointer() bool {
+	if f.Repeated || f.Map {
+		return false
+	}
+
+	return f.Optional || f.Elem.Kind == KindMessage || f.Elem.Kind == KindTimestamp ||
+		f.Elem.Kind == KindDuration || f.Elem.Kind == KindWrapper
+}
+
+// Message is a generated struct.
+type Message struct {
+	// Name is the Go name in the doc package.
+	Name       string
+	RPC        protogen.GoIdent
+	Comments   protogen.Comments
+	Deprecated bool
+	Fields     []*Field
+	// Schema is the naviga.message_schema option.
+	Schema MessageSchema
+	// EmptyCollections is the Go name of the rpc field that lists the
+	// empty collections, if any.
+	EmptyCollections string
+
+	Desc protoreflect.MessageDescriptor
+	gen  *protogen.Message
+}
+
+// Enum is a generated string type.
+type Enum struct {
+	// Name is the Go name in the doc package.
+	Name     string
+	RPC      protogen.GoIdent
+	Comments protogen.Comments
+	Values   []*EnumValue
+
+	Desc protoreflect.EnumDescriptor
+}
+
+// EnumValue is a generated constant. The zero value is represented by
+// an empty string, and the other values by their protobuf names.
+type EnumValue struct {
+	// Name is the Go name of the constant.
+	Name       string
+	Value      string
+	Comments   protogen.Comments
+	Deprecated bool
+}
+
+// Model is everything that's generated from a set of proto files.
+type Model struct {
+	DocPackage protogen.GoImportPath
+	RPCPackage protogen.GoImportPath
+	Messages   []*Message
+	Enums      []*Enum
+
+	messages map[protoreflect.FullName]*Message
+	enums    map[protoreflect.FullName]*Enum
+	options  *optionReader
+}
+
+// Message returns the named message.
+func (m *Model) Message(name string) *Message {
+	for _, msg := range m.Messages {
+		if msg.Name == name {
+			return msg
+		}
+	}
+
+	return nil
+}
+
+func buildModel(
+	files []*protogen.File, docPackage protogen.GoImportPath, options *optionReader,
+) (*Model, error) {
+	m := Model{
+		DocPackage: docPackage,
+		RPCPackage: files[0].GoImportPath,
+		messages:   make(map[protoreflect.FullName]*Message),
+		enums:      make(map[protoreflect.FullName]*Enum),
+		options:    options,
+	}
+
+	// Declare all types first so that fields can refer to types that
+	// are declared later.
+	for _, f := range files {
+		if f.GoImportPath != m.RPCPackage {
+			return nil, fmt.Errorf("%s: all files must have the go_package %q, got %q",
+				f.Desc.Path(), m.RPCPackage, f.GoImportPath)
+		}
+
+		for _, e := range f.Enums {
+			m.addEnum(e)
+		}
+
+		m.addMessages(f.Messages)
+	}
+
+	for _, msg := range m.Messages {
+		var err error
+
+		msg.Schema, err = options.messageSchema(msg.Desc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", msg.Desc.FullName(), err)
+		}
+
+		err = m.addFields(msg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &m, nil
+}
+
+func (m *Model) addMessages(messages []*protogen.Message) {
+	for _, msg := range messages {
+		if msg.Desc.IsMapEntry() {
+			continue
+		}
+
+		gm := Message{
+			Name:       docName(msg.GoIdent.GoName),
+			RPC:        msg.GoIdent,
+			Comments:   msg.Comments.Leading,
+			Deprecated: msg.Desc.Options().(*descriptorpb.MessageOptions).GetDeprecated(),
+			Desc:       msg.Desc,
+			gen:        msg,
+		}
+
+		m.Messages = append(m.Messages, &gm)
+		m.messages[msg.Desc.FullName()] = &gm
+
+		for _, e := range msg.Enums {
+			m.addEnum(e)
+		}
+
+		m.addMessages(msg.Messages)
+	}
+}
+
+func (m *Model) addEnum(e *protogen.Enum) {
+	ge := Enum{
+		Name:     docName(e.GoIdent.GoName),
+		RPC:      e.GoIdent,
+		Comments: e.Comments.Leading,
+		Desc:     e.Desc,
+	}
+
+	prefix := upperSnake(string(e.Desc.Name())) + "_"
+
+	for _, v := range e.Values {
+		value := string(v.Desc.Name())
+		if v.Desc.Number() == 0 {
+			value = ""
+		}
+
+		ge.Values = append(ge.Values, &EnumValue{
+			Name:       ge.Name + camelCase(strings.TrimPrefix(string(v.Desc.Name()), prefix)),
+			Value:      value,
+			Comments:   v.Comments.Leading,
+			Deprecated: v.Desc.Options().(*descriptorpb.EnumValueOptions).GetDeprecated(),
+		})
+	}
+
+	m.Enums = append(m.Enums, &ge)
+	m.enums[e.Desc.FullName()] = &ge
+}
+
+func (m *Model) addFields(msg *Message) error {
+	// Timestamp field names to the Go names of their offset fields.
+	offsets := make(map[string]string)
+
+	for _, field := range msg.gen.Fields {
+		fd := field.Desc
+
+		conversion, err := m.options.fieldConversion(fd)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fd.FullName(), err)
+		}
+
+		switch {
+		case conversion.OffsetOf != "":
+			if fd.Kind() != protoreflect.Int32Kind || fd.IsList() {
+				return fmt.Errorf("%s: offset fields must be int32", fd.FullName())
+			}
+
+			offsets[conversion.OffsetOf] = field.GoName
+
+			continue
+		case conversion.EmptyCollections:
+			if fd.Kind() != protoreflect.StringKind || !fd.IsList() {
+				return fmt.Errorf("%s: empty collection fields must be repeated strings", fd.FullName())
+			}
+
+			msg.EmptyCollections = field.GoName
+
+			continue
+		}
+
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			return fmt.Errorf("%s: oneof fields are not supported", fd.FullName())
+		}
+
+		f := Field{
+			Name:       docName(field.GoName),
+			RPCName:    field.GoName,
+			JSONName:   string(fd.Name()),
+			Comments:   field.Comments.Leading,
+			Deprecated: fd.Options().(*descriptorpb.FieldOptions).GetDeprecated(),
+			Repeated:   fd.IsList(),
+			Map:        fd.IsMap(),
+			Optional:   fd.HasOptionalKeyword() && fd.Message() == nil && fd.Kind() != protoreflect.BytesKind,
+			Desc:       fd,
+		}
+
+		if f.Map {
+			f.Key, err = m.elem(fd.MapKey())
+			if err != nil {
+				return err
+			}
+
+			f.Elem, err = m.elem(fd.MapValue())
+		} else {
+			f.Elem, err = m.elem(fd)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if f.Optional && f.Elem.Kind != KindScalar {
+			return fmt.Errorf("%s: optional %s fields are not supported", fd.FullName(), fd.Kind())
+		}
+
+		f.Schema, err = m.options.fieldSchema(fd)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fd.FullName(), err)
+		}
+
+		msg.Fields = append(msg.Fields, &f)
+	}
+
+	for name, goName := range offsets {
+		var target *Field
+
+		for _, f := range msg.Fields {
+			if f.JSONName == name {
+				target = f
+			}
+		}
+
+		if target == nil || target.Elem.Kind != KindTimestamp || target.Collection() {
+			return fmt.Errorf("%s: offset_of must name a timestamp field, got %q",
+				msg.Desc.FullName(), name)
+		}
+
+		target.OffsetRPCName = goName
+	}
+
+	return nil
+}
+
+// wellKnown maps the supported well-known types to their kind and Go
+// types. Wrappers are represented by the type they wrap.
+var wellKnown = map[protoreflect.FullName]struct {
+	kind   Kind
+	name   string
+	doc    protogen.GoIdent
+	scalar protoreflect.Kind
+}{
+	"google.protobuf.Timestamp":   {KindTimestamp, "Time", timeIdent("Time"), 0},
+	"google.protobuf.Duration":    {KindDuration, "Duration", timeIdent("Duration"), 0},
+	"google.protobuf.DoubleValue": {KindWrapper, "DoubleValue", builtin("float64"), protoreflect.DoubleKind},
+	"google.protobuf.FloatValue":  {KindWrapper, "FloatValue", builtin("float32"), protoreflect.FloatKind},
+	"google.protobuf.Int64Value":  {KindWrapper, "Int64Value", builtin("int64"), protoreflect.Int64Kind},
+	"google.protobuf.UInt64Value": {KindWrapper, "UInt64Value", builtin("uint64"), protoreflect.Uint64Kind},
+	"google.protobuf.Int32Value":  {KindWrapper, "Int32Value", builtin("int32"), protoreflect.Int32Kind},
+	"google.protobuf.UInt32Value": {KindWrapper, "UInt32Value", builtin("uint32"), protoreflect.Uint32Kind},
+	"google.protobuf.BoolValue":   {KindWrapper, "BoolValue", builtin("bool"), protoreflect.BoolKind},
+	"google.protobuf.StringValue": {KindWrapper, "StringValue", builtin("string"), protoreflect.StringKind},
+	"google.protobuf.BytesValue":  {KindWrapper, "BytesValue", builtin("[]byte"), protoreflect.BytesKind},
+}
+
+func (m *Model) elem(fd protoreflect.FieldDescriptor) (*Elem, error) {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		e, ok := m.enums[fd.Enum().FullName()]
+		if !ok {
+			return nil, fmt.Errorf("%s: enum %s isn't declared in the generated files",
+				fd.FullName(), fd.Enum().FullName())
+		}
+
+		return &Elem{
+			Kind: KindEnum,
+			Name: e.Name,
+			Doc:  m.DocPackage.Ident(e.Name),
+			RPC:  e.RPC,
+			Enum: e,
+		}, nil
+	case protoreflect.MessageKind:
+		name := fd.Message().FullName()
+
+		if wkt, ok := wellKnown[name]; ok {
+			return &Elem{
+				Kind:   wkt.kind,
+				Name:   wkt.name,
+				Doc:    wkt.doc,
+				RPC:    wellKnownIdent(fd.Message()),
+				Scalar: wkt.scalar,
+			}, nil
+		}
+
+		msg, ok := m.messages[name]
+		if !ok {
+			return nil, fmt.Errorf("%s: message type %s is not supported", fd.FullName(), name)
+		}
+
+		return &Elem{
+			Kind:    KindMessage,
+			Name:    msg.Name,
+			Doc:     m.DocPackage.Ident(msg.Name),
+			RPC:     msg.RPC,
+			Message: msg,
+		}, nil
+	case protoreflect.GroupKind:
+		return nil, fmt.Errorf("%s: groups are not supported", fd.FullName())
+	}
+
+	t, ok := scalarTypes[fd.Kind()]
+	if !ok {
+		return nil, fmt.Errorf("%s: %s fields are not supported", fd.FullName(), fd.Kind())
+	}
+
+	return &Elem{
+		Kind:   KindScalar,
+		Name:   strings.Title(strings.TrimPrefix(t, "[]")),
+		Doc:    builtin(t),
+		RPC:    builtin(t),
+		Scalar: fd.Kind(),
+	}, nil
+}
+
+var scalarTypes = map[protoreflect.Kind]string{
+	protoreflect.BoolKind:     "bool",
+	protoreflect.Int32Kind:    "int32",
+	protoreflect.Sint32Kind:   "int32",
+	protoreflect.Sfixed32Kind: "int32",
+	protoreflect.Uint32Kind:   "uint32",
+	protoreflect.Fixed32Kind:  "uint32",
+	protoreflect.Int64Kind:    "int64",
+	protoreflect.Sint64Kind:   "int64",
+	protoreflect.Sfixed64Kind: "int64",
+	protoreflect.Uint64Kind:   "uint64",
+	protoreflect.Fixed64Kind:  "uint64",
+	protoreflect.FloatKind:    "float32",
+	protoreflect.DoubleKind:   "float64",
+	protoreflect.StringKind:   "string",
+	protoreflect.BytesKind:    "[]byte",
+}
+
+// builtin returns an identifier for a predeclared type.
+func builtin(name string) protogen.GoIdent {
+	return protogen.GoIdent{GoName: name}
+}
+
+func timeIdent(name string) protogen.GoIdent {
+	return protogen.GoImportPath("time").Ident(name)
+}
+
+// wellKnownIdent returns the Go type that protoc-gen-go uses for a
+// well-known type.
+func wellKnownIdent(md protoreflect.MessageDescriptor) protogen.GoIdent {
+	pkg := map[protoreflect.FullName]protogen.GoImportPath{
+		"google.protobuf.Timestamp": "google.golang.org/protobuf/types/known/timestamppb",
+		"google.protobuf.Duration":  "google.golang.org/protobuf/types/known/durationpb",
+	}[md.FullName()]
+
+	if pkg == "" {
+		pkg = "google.golang.org/protobuf/types/known/wrapperspb"
+	}
+
+	return pkg.Ident(string(md.Name()))
+}
+
+// initialisms are the words that are all caps in doc names.
+var initialisms = map[string]string{
+	"Id":   "ID",
+	"Uri":  "URI",
+	"Url":  "URL",
+	"Uuid": "UUID",
+}
+
+// docName returns the name used in the doc package for a Go name from
+// the rpc package. Nested types lose their underscores, and
+// initialisms are upper cased, f.ex. "Block_ParentId" becomes
+// "BlockParentID".
+func docName(name string) string {
+	var (
+		b    strings.Builder
+		word []rune
+	)
+
+	flush := func() {
+		w := string(word)
+		if t, ok := initialisms[w]; ok {
+			w = t
+		}
+
+		b.WriteString(w)
+
+		word = word[:0]
+	}
+
+	for _, r := range strings.ReplaceAll(name, "_", "") {
+		if r >= 'A' && r <= 'Z' && len(word) > 0 {
+			flush()
+		}
+
+		word = append(word, r)
+	}
+
+	flush()
+
+	return b.String()
+}
+
+// camelCase turns snake_case and SCREAMING_SNAKE into CamelCase, f.ex.
+// "FORMAT_NAVIGADOC" into "FormatNavigadoc".
+func camelCase(name string) string {
+	var b strings.Builder
+
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+
+		if strings.ToUpper(part) == part {
+			part = strings.ToLower(part)
+		}
+
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return b.String()
+}
+
+// upperSnake turns CamelCase into SCREAMING_SNAKE, f.ex.
+// "ConvertFormat" into "CONVERT_FORMAT".
+func upperSnake(name string) string {
+	var b strings.Builder
+
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return strings.ToUpper(b.String())
+}
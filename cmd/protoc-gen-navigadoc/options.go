@@ -0,0 +1,167 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// The custom options declared in rpc/options.proto. They are read
+// dynamically from the descriptors in the request, so that the plugin
+// doesn't depend on the generated rpc package.
+const (
+	fieldSchemaOption   protoreflect.FullName = "naviga.field_schema"
+	messageSchemaOption protoreflect.FullName = "naviga.message_schema"
+	conversionOption    protoreflect.FullName = "naviga.conversion"
+)
+
+// FieldSchema is the naviga.field_schema option of a field.
+type FieldSchema struct {
+	Required bool
+	Format   string
+	Pattern  string
+	Enum     []string
+}
+
+// MessageSchema is the naviga.message_schema option of a message.
+type MessageSchema struct {
+	// AdditionalProperties is nil if the option isn't set.
+	AdditionalProperties *bool
+}
+
+// FieldConversion is the naviga.conversion option of a field.
+type FieldConversion struct {
+	OffsetOf         string
+	EmptyCollections bool
+}
+
+// Companion is true for fields that are only used by the conversion.
+func (c FieldConversion) Companion() bool {
+	return c.OffsetOf != "" || c.EmptyCollections
+}
+
+// optionReader reads custom options from descriptor options.
+type optionReader struct {
+	types protoregistry.Types
+}
+
+func newOptionReader(files []*protogen.File) (*optionReader, error) {
+	var r optionReader
+
+	for _, f := range files {
+		for _, x := range f.Extensions {
+			name := x.Desc.FullName()
+			if name != fieldSchemaOption && name != messageSchemaOption && name != conversionOption {
+				continue
+			}
+
+			err := r.types.RegisterExtension(dynamicpb.NewExtensionType(x.Desc))
+			if err != nil {
+				return nil, fmt.Errorf("failed to register %s: %w", name, err)
+			}
+		}
+	}
+
+	return &r, nil
+}
+
+// option returns the value of the named option, or nil if it isn't set.
+func (r *optionReader) option(opts proto.Message, name protoreflect.FullName) (protoreflect.Message, error) {
+	_, err := r.types.FindExtensionByName(name)
+	if errors.Is(err, protoregistry.NotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Options are parsed before the extensions are known, so they
+	// have to be parsed again to resolve them.
+	data, err := proto.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := opts.ProtoReflect().New().Interface()
+
+	err = proto.UnmarshalOptions{Resolver: &r.types}.Unmarshal(data, resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	// proto.HasExtension can't be used as the extension extends the
+	// descriptor.proto from the request, not the compiled in one.
+	var value protoreflect.Message
+
+	resolved.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.FullName() == name {
+			value = v.Message()
+			return false
+		}
+
+		return true
+	})
+
+	return value, nil
+}
+
+func (r *optionReader) fieldSchema(fd protoreflect.FieldDescriptor) (FieldSchema, error) {
+	var s FieldSchema
+
+	m, err := r.option(fd.Options(), fieldSchemaOption)
+	if err != nil || m == nil {
+		return s, err
+	}
+
+	fields := m.Descriptor().Fields()
+
+	s.Required = m.Get(fields.ByName("required")).Bool()
+	s.Format = m.Get(fields.ByName("format")).String()
+	s.Pattern = m.Get(fields.ByName("pattern")).String()
+
+	enum := m.Get(fields.ByName("enum")).List()
+	for i := 0; i < enum.Len(); i++ {
+		s.Enum = append(s.Enum, enum.Get(i).String())
+	}
+
+	return s, nil
+}
+
+func (r *optionReader) messageSchema(md protoreflect.MessageDescriptor) (MessageSchema, error) {
+	var s MessageSchema
+
+	m, err := r.option(md.Options(), messageSchemaOption)
+	if err != nil || m == nil {
+		return s, err
+	}
+
+	field := m.Descriptor().Fields().ByName("additional_properties")
+	if m.Has(field) {
+		v := m.Get(field).Bool()
+		s.AdditionalProperties = &v
+	}
+
+	return s, nil
+}
+
+func (r *optionReader) fieldConversion(fd protoreflect.FieldDescriptor) (FieldConversion, error) {
+	var c FieldConversion
+
+	m, err := r.option(fd.Options(), conversionOption)
+	if err != nil || m == nil {
+		return c, err
+	}
+
+	fields := m.Descriptor().Fields()
+
+	c.OffsetOf = m.Get(fields.ByName("offset_of")).String()
+	c.EmptyCollections = m.Get(fields.ByName("empty_collections")).Bool()
+
+	return c, nil
+}
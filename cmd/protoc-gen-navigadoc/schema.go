@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// JSONSchema is a JSON schema object.
+type JSONSchema map[string]interface{}
+
+// draft07 is the JSON schema dialect of the generated schema. It's the
+// latest draft that gojsonschema, which the validation functions use,
+// supports.
+const draft07 = "http://json-schema.org/draft-07/schema#"
+
+// generateSchema writes the JSON schema for the doc package JSON. The
+// root message is the schema itself, and the other messages are
+// definitions in "definitions". Descriptions come from the proto comments,
+// and constraints from the naviga.field_schema and
+// naviga.message_schema options.
+func generateSchema(g *protogen.GeneratedFile, m *Model, id string, rootName string) error {
+	root := m.Message(rootName)
+	if root == nil {
+		return fmt.Errorf("unknown root message %q", rootName)
+	}
+
+	schema := JSONSchema{
+		"$schema":     draft07,
+		"$id":         id,
+		"title":       "Navigadoc",
+		"description": "Navigadoc Schema",
+	}
+
+	defs := JSONSchema{}
+
+	for _, msg := range m.Messages {
+		if msg == root {
+			continue
+		}
+
+		def := messageSchema(msg, root)
+		def["title"] = msg.Name
+
+		defs[definitionName(msg)] = def
+	}
+
+	for k, v := range messageSchema(root, root) {
+		schema[k] = v
+	}
+
+	if len(defs) > 0 {
+		schema["definitions"] = defs
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = g.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// definitionName is the name of a message in the schema definitions,
+// f.ex. "block".
+func definitionName(msg *Message) string {
+	return strings.ToLower(msg.Name[:1]) + msg.Name[1:]
+}
+
+func messageSchema(msg *Message, root *Message) JSONSchema {
+	var required []string
+
+	properties := JSONSchema{}
+
+	for _, f := range msg.Fields {
+		properties[f.JSONName] = fieldSchema(f, root)
+
+		if f.Schema.Required {
+			required = append(required, f.JSONName)
+		}
+	}
+
+	s := JSONSchema{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	if d := description(msg.Comments); d != "" {
+		s["description"] = d
+	}
+
+	if msg.Deprecated {
+		s["deprecated"] = true
+	}
+
+	if len(required) > 0 {
+		s["required"] = required
+	}
+
+	if msg.Schema.AdditionalProperties != nil {
+		s["additionalProperties"] = *msg.Schema.AdditionalProperties
+	}
+
+	return s
+}
+
+func fieldSchema(f *Field, root *Message) JSONSchema {
+	value := elemSchema(f.Elem, root)
+
+	for k, v := range constraints(f.Schema) {
+		value[k] = v
+	}
+
+	var s JSONSchema
+
+	switch {
+	case f.Map:
+		s = JSONSchema{"type": "object", "additionalProperties": value}
+	case f.Repeated:
+		s = JSONSchema{"type": "array", "items": value}
+	default:
+		s = value
+	}
+
+	if d := description(f.Comments); d != "" {
+		s["description"] = d
+	}
+
+	if f.Deprecated {
+		s["deprecated"] = true
+	}
+
+	// Keywords next to a "$ref" are ignored in draft-07.
+	if ref, ok := s["$ref"]; ok && len(s) > 1 {
+		delete(s, "$ref")
+		s["allOf"] = []JSONSchema{{"$ref": ref}}
+	}
+
+	return s
+}
+
+// constraints returns the schema keywords for the field options, they
+// apply to the values of lists and maps.
+func constraints(o FieldSchema) JSONSchema {
+	s := JSONSchema{}
+
+	if o.Format != "" {
+		s["format"] = o.Format
+	}
+
+	if o.Pattern != "" {
+		s["pattern"] = o.Pattern
+	}
+
+	if len(o.Enum) > 0 {
+		s["enum"] = o.Enum
+	}
+
+	return s
+}
+
+// description turns proto comments into a description. Lines are
+// joined, paragraphs are kept.
+func description(c protogen.Comments) string {
+	var (
+		paragraphs []string
+		lines      []string
+	)
+
+	for _, line := range strings.Split(string(c), "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			if len(lines) > 0 {
+				paragraphs = append(paragraphs, strings.Join(lines, " "))
+				lines = nil
+			}
+
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if len(lines) > 0 {
+		paragraphs = append(paragraphs, strings.Join(lines, " "))
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+func elemSchema(e *Elem, root *Message) JSONSchema {
+	switch e.Kind {
+	case KindMessage:
+		if e.Message == root {
+			return JSONSchema{"$ref": "#"}
+		}
+
+		return JSONSchema{"$ref": "#/definitions/" + definitionName(e.Message)}
+	case KindEnum:
+		var values []string
+
+		for _, v := range e.Enum.Values {
+			if v.Value != "" {
+				values = append(values, v.Value)
+			}
+		}
+
+		return JSONSchema{"type": "string", "enum": values}
+	case KindTimestamp:
+		return JSONSchema{"type": "string", "format": "date-time"}
+	case KindDuration:
+		// time.Duration is marshalled as nanoseconds.
+		return JSONSchema{"type": "integer"}
+	}
+
+	switch e.Scalar {
+	case protoreflect.BoolKind:
+		return JSONSchema{"type": "boolean"}
+	case protoreflect.StringKind:
+		return JSONSchema{"type": "string"}
+	case protoreflect.BytesKind:
+		return JSONSchema{"type": "string", "contentEncoding": "base64"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return JSONSchema{"type": "number"}
+	}
+
+	return JSONSchema{"type": "integer"}
+}
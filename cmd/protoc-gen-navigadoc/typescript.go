@@ -0,0 +1,160 @@
+package main
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// generateTypeScript writes TypeScript declarations for the JSON of the
+// doc package. Enums become string literal unions and messages become
+// interfaces with the same property names as the doc JSON tags.
+//
+// All properties are optional as empty values are omitted from the
+// JSON, except the ones that the schema requires.
+func generateTypeScript(g *protogen.GeneratedFile, m *Model) {
+	g.P(generatedHeader)
+
+	var timestamps bool
+
+	for _, msg := range m.Messages {
+		for _, f := range msg.Fields {
+			timestamps = timestamps || f.Elem.Kind == KindTimestamp
+		}
+	}
+
+	if timestamps {
+		g.P()
+		g.P("/** Timestamp is an RFC 3339 date and time, f.ex. \"2022-03-01T12:00:00+01:00\". */")
+		g.P("export type Timestamp = string;")
+	}
+
+	for _, e := range m.Enums {
+		var values []string
+
+		for _, v := range e.Values {
+			if v.Value != "" {
+				values = append(values, quote(v.Value))
+			}
+		}
+
+		if len(values) == 0 {
+			values = append(values, "never")
+		}
+
+		g.P()
+		writeTSComments(g, "", e.Comments, false)
+		g.P("export type ", e.Name, " = ", strings.Join(values, " | "), ";")
+	}
+
+	for _, msg := range m.Messages {
+		g.P()
+		writeTSComments(g, "", msg.Comments, msg.Deprecated)
+		g.P("export interface ", msg.Name, " {")
+
+		for _, f := range msg.Fields {
+			optional := "?"
+			if f.Schema.Required {
+				optional = ""
+			}
+
+			writeTSComments(g, "  ", f.Comments, f.Deprecated)
+			g.P("  ", tsPropertyName(f.JSONName), optional, ": ", tsFieldType(f), ";")
+		}
+
+		g.P("}")
+	}
+}
+
+// tsFieldType is the TypeScript type of a field.
+func tsFieldType(f *Field) string {
+	switch {
+	case f.Map:
+		// JSON object keys are always strings.
+		return "{ [key: string]: " + tsElemType(f.Elem) + " }"
+	case f.Repeated:
+		return tsElemType(f.Elem) + "[]"
+	}
+
+	return tsElemType(f.Elem)
+}
+
+// tsElemType is the TypeScript type of a value as it's marshalled by
+// encoding/json.
+func tsElemType(e *Elem) string {
+	switch e.Kind {
+	case KindMessage:
+		return e.Message.Name
+	case KindEnum:
+		return e.Enum.Name
+	case KindTimestamp:
+		return "Timestamp"
+	case KindDuration:
+		// time.Duration is marshalled as nanoseconds.
+		return "number"
+	}
+
+	switch e.Scalar {
+	case protoreflect.BoolKind:
+		return "boolean"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		// []byte is marshalled as base64.
+		return "string"
+	}
+
+	return "number"
+}
+
+// tsPropertyName quotes JSON names that aren't valid identifiers.
+func tsPropertyName(name string) string {
+	for i, r := range name {
+		valid := r == '_' || r == '$' ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
+			(i > 0 && r >= '0' && r <= '9')
+
+		if !valid {
+			return quote(name)
+		}
+	}
+
+	return name
+}
+
+// writeTSComments writes proto comments as a JSDoc comment.
+func writeTSComments(g *protogen.GeneratedFile, indent string, comments protogen.Comments, deprecated bool) {
+	var lines []string
+
+	text := strings.TrimSuffix(string(comments), "\n")
+	if text != "" {
+		for _, line := range strings.Split(text, "\n") {
+			lines = append(lines, strings.ReplaceAll(strings.TrimPrefix(line, " "), "*/", "*\\/"))
+		}
+	}
+
+	if deprecated {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+
+		lines = append(lines, "@deprecated")
+	}
+
+	switch len(lines) {
+	case 0:
+		return
+	case 1:
+		g.P(indent, "/** ", lines[0], " */")
+		return
+	}
+
+	g.P(indent, "/**")
+
+	for _, line := range lines {
+		g.P(strings.TrimRight(indent+" * "+line, " "))
+	}
+
+	g.P(indent, " */")
+}
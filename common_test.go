@@ -1,7 +1,11 @@
 package navigadoc_test
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"testing"
+
+	"github.com/navigacontentlab/navigadoc/doc"
 )
 
 func must(t *testing.T, err error, msg string) {
@@ -11,3 +15,16 @@ func must(t *testing.T, err error, msg string) {
 		t.Fatalf("%s: %v", msg, err)
 	}
 }
+
+func loadDocument(t *testing.T, path string) *doc.Document {
+	t.Helper()
+
+	testData, err := ioutil.ReadFile(path)
+	must(t, err, "could not open testfile")
+
+	document := &doc.Document{}
+	err = json.Unmarshal(testData, document)
+	must(t, err, "could not unmarshal doc")
+
+	return document
+}
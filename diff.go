@@ -0,0 +1,464 @@
+package navigadoc
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// ChangeKind describes what happened to an element between two
+// documents.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+	ChangeMoved    ChangeKind = "moved"
+)
+
+// Change is a single difference between two documents.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// Path is a JSON pointer to the changed element. It points into
+	// the old document for removals and into the new document for
+	// everything else.
+	Path string `json:"path"`
+	// OldPath is the position of a moved block in the old document.
+	OldPath string `json:"oldPath,omitempty"`
+	// Field is the changed field of a document, block or property,
+	// f.ex. "title" or "data.width". It's empty when a whole block or
+	// property was added, removed or moved.
+	Field    string      `json:"field,omitempty"`
+	OldValue interface{} `json:"oldValue,omitempty"`
+	NewValue interface{} `json:"newValue,omitempty"`
+}
+
+// String returns a single line description of the change.
+func (c Change) String() string {
+	target := c.Path
+
+	switch {
+	case c.Field == "":
+	case target == "":
+		target = c.Field
+	default:
+		target += " " + c.Field
+	}
+
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", target, describeValue(c.NewValue))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", target, describeValue(c.OldValue))
+	case ChangeMoved:
+		return fmt.Sprintf("> %s: moved from %s", target, c.OldPath)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", target,
+			describeValue(c.OldValue), describeValue(c.NewValue))
+	}
+}
+
+func describeValue(v interface{}) string {
+	switch val := v.(type) {
+	case doc.Block:
+		desc := val.Type
+		if desc == "" {
+			desc = "block"
+		}
+
+		for _, s := range []string{val.ID, val.Rel, val.UUID, val.URI} {
+			if s != "" {
+				desc += " " + s
+			}
+		}
+
+		return desc
+	case doc.Property:
+		return fmt.Sprintf("%s=%q", val.Name, val.Value)
+	case nil:
+		return "<nil>"
+	default:
+		return fmt.Sprintf("%q", fmt.Sprint(val))
+	}
+}
+
+// Changes is the result of Diff.
+type Changes []Change
+
+// String renders the changes as text, one change per line.
+func (c Changes) String() string {
+	lines := make([]string, len(c))
+	for i := range c {
+		lines[i] = c[i].String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Diff compares two documents and returns the changes needed to get
+// from a to b.
+//
+// Blocks are matched within the same list by ID, then by UUID and rel,
+// then by URI and rel, and last by position among blocks of the same
+// type. A block that has been moved to another list or parent is
+// reported as removed and added.
+func Diff(a, b *doc.Document) Changes {
+	if a == nil {
+		a = &doc.Document{}
+	}
+
+	if b == nil {
+		b = &doc.Document{}
+	}
+
+	d := differ{}
+
+	d.diffHeader(a, b)
+	d.diffProperties(a.Properties, b.Properties)
+	d.diffBlockList("", "", SectionContent, a.Content, b.Content)
+	d.diffBlockList("", "", SectionMeta, a.Meta, b.Meta)
+	d.diffBlockList("", "", SectionLinks, a.Links, b.Links)
+
+	return d.changes
+}
+
+type differ struct {
+	changes Changes
+}
+
+func (d *differ) add(c Change) {
+	d.changes = append(d.changes, c)
+}
+
+func (d *differ) diffString(path, field, a, b string) {
+	if a == b {
+		return
+	}
+
+	d.add(Change{
+		Kind:     ChangeModified,
+		Path:     path,
+		Field:    field,
+		OldValue: a,
+		NewValue: b,
+	})
+}
+
+func (d *differ) diffTime(field string, a, b *time.Time) {
+	if a == nil && b == nil {
+		return
+	}
+
+	if a != nil && b != nil && a.Equal(*b) {
+		return
+	}
+
+	c := Change{Kind: ChangeModified, Path: "", Field: field}
+
+	if a != nil {
+		c.OldValue = a.Format(time.RFC3339Nano)
+	}
+
+	if b != nil {
+		c.NewValue = b.Format(time.RFC3339Nano)
+	}
+
+	d.add(c)
+}
+
+func (d *differ) diffHeader(a, b *doc.Document) {
+	d.diffString("", "uuid", a.UUID, b.UUID)
+	d.diffString("", "type", a.Type, b.Type)
+	d.diffString("", "uri", a.URI, b.URI)
+	d.diffString("", "url", a.URL, b.URL)
+	d.diffString("", "title", a.Title, b.Title)
+	d.diffString("", "path", a.Path, b.Path)
+
+	if !stringSlicesEqual(a.Products, b.Products) {
+		d.add(Change{
+			Kind:     ChangeModified,
+			Field:    "products",
+			OldValue: a.Products,
+			NewValue: b.Products,
+		})
+	}
+
+	d.diffTime("created", a.Created, b.Created)
+	d.diffTime("modified", a.Modified, b.Modified)
+	d.diffTime("published", a.Published, b.Published)
+	d.diffString("", "source", a.Source, b.Source)
+	d.diffString("", "language", a.Language, b.Language)
+	d.diffString("", "status", a.Status, b.Status)
+	d.diffTime("unpublished", a.Unpublished, b.Unpublished)
+	d.diffString("", "provider", a.Provider, b.Provider)
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *differ) diffProperties(a, b []doc.Property) {
+	// Properties are matched on name, repeated names are matched in
+	// the order they occur.
+	oldIndex := make(map[string][]int, len(a))
+	for i, p := range a {
+		oldIndex[p.Name] = append(oldIndex[p.Name], i)
+	}
+
+	seen := make(map[int]bool, len(b))
+
+	for i, p := range b {
+		path := "/properties/" + strconv.Itoa(i)
+
+		candidates := oldIndex[p.Name]
+		if len(candidates) == 0 {
+			d.add(Change{Kind: ChangeAdded, Path: path, NewValue: p})
+			continue
+		}
+
+		j := candidates[0]
+		oldIndex[p.Name] = candidates[1:]
+		seen[j] = true
+
+		d.diffString(path, "value", a[j].Value, p.Value)
+		d.diffMap(path, "parameters.", a[j].Parameters, p.Parameters)
+	}
+
+	for i, p := range a {
+		if !seen[i] {
+			d.add(Change{
+				Kind:     ChangeRemoved,
+				Path:     "/properties/" + strconv.Itoa(i),
+				OldValue: p,
+			})
+		}
+	}
+}
+
+func (d *differ) diffMap(path, prefix string, a, b map[string]string) {
+	keys := make([]string, 0, len(a)+len(b))
+
+	for k := range a {
+		keys = append(keys, k)
+	}
+
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		oldValue, inOld := a[k]
+		newValue, inNew := b[k]
+
+		switch {
+		case !inOld:
+			d.add(Change{Kind: ChangeAdded, Path: path, Field: prefix + k, NewValue: newValue})
+		case !inNew:
+			d.add(Change{Kind: ChangeRemoved, Path: path, Field: prefix + k, OldValue: oldValue})
+		default:
+			d.diffString(path, prefix+k, oldValue, newValue)
+		}
+	}
+}
+
+func (d *differ) diffBlock(aPath, bPath string, a, b *doc.Block) {
+	d.diffString(bPath, "id", a.ID, b.ID)
+	d.diffString(bPath, "uuid", a.UUID, b.UUID)
+	d.diffString(bPath, "uri", a.URI, b.URI)
+	d.diffString(bPath, "url", a.URL, b.URL)
+	d.diffString(bPath, "type", a.Type, b.Type)
+	d.diffString(bPath, "title", a.Title, b.Title)
+	d.diffString(bPath, "rel", a.Rel, b.Rel)
+	d.diffString(bPath, "name", a.Name, b.Name)
+	d.diffString(bPath, "value", a.Value, b.Value)
+	d.diffString(bPath, "contentType", a.ContentType, b.ContentType)
+	d.diffString(bPath, "role", a.Role, b.Role)
+	d.diffMap(bPath, "data.", a.Data, b.Data)
+
+	d.diffBlockList(aPath, bPath, SectionContent, a.Content, b.Content)
+	d.diffBlockList(aPath, bPath, SectionMeta, a.Meta, b.Meta)
+	d.diffBlockList(aPath, bPath, SectionLinks, a.Links, b.Links)
+}
+
+func (d *differ) diffBlockList(aParent, bParent string, section Section, a, b []doc.Block) {
+	aPath := func(i int) string {
+		return aParent + "/" + string(section) + "/" + strconv.Itoa(i)
+	}
+	bPath := func(i int) string {
+		return bParent + "/" + string(section) + "/" + strconv.Itoa(i)
+	}
+
+	matches := MatchBlocks(a, b)
+
+	matchedA := make(map[int]bool, len(matches))
+	matchedB := make(map[int]bool, len(matches))
+
+	for _, m := range matches {
+		matchedA[m.A] = true
+		matchedB[m.B] = true
+	}
+
+	for i := range a {
+		if !matchedA[i] {
+			d.add(Change{Kind: ChangeRemoved, Path: aPath(i), OldValue: a[i]})
+		}
+	}
+
+	stable := stableMatches(matches)
+
+	for j := range b {
+		if !matchedB[j] {
+			d.add(Change{Kind: ChangeAdded, Path: bPath(j), NewValue: b[j]})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].B < matches[j].B
+	})
+
+	for _, m := range matches {
+		if !stable[m] {
+			d.add(Change{Kind: ChangeMoved, Path: bPath(m.B), OldPath: aPath(m.A)})
+		}
+
+		d.diffBlock(aPath(m.A), bPath(m.B), &a[m.A], &b[m.B])
+	}
+}
+
+// BlockMatch pairs the index of a block in one list with the index of
+// the same block in another list.
+type BlockMatch struct {
+	A int
+	B int
+}
+
+// MatchBlocks pairs up blocks in two lists that represent the same
+// block. Blocks are matched by ID, then by UUID and rel, then by URI
+// and rel, and last by position among the remaining blocks of the same
+// type. The result is ordered by the index in a.
+func MatchBlocks(a, b []doc.Block) []BlockMatch {
+	usedA := make([]bool, len(a))
+	usedB := make([]bool, len(b))
+
+	var matches []BlockMatch
+
+	keyed := func(key func(b *doc.Block) string) {
+		index := make(map[string][]int)
+
+		for j := range b {
+			if usedB[j] {
+				continue
+			}
+
+			if k := key(&b[j]); k != "" {
+				index[k] = append(index[k], j)
+			}
+		}
+
+		for i := range a {
+			if usedA[i] {
+				continue
+			}
+
+			k := key(&a[i])
+			if k == "" || len(index[k]) == 0 {
+				continue
+			}
+
+			j := index[k][0]
+			index[k] = index[k][1:]
+			usedA[i], usedB[j] = true, true
+			matches = append(matches, BlockMatch{A: i, B: j})
+		}
+	}
+
+	keyed(func(b *doc.Block) string { return b.ID })
+	keyed(func(b *doc.Block) string {
+		if b.UUID == "" {
+			return ""
+		}
+
+		return strings.ToLower(b.UUID) + "\x00" + b.Rel
+	})
+	keyed(func(b *doc.Block) string {
+		if b.URI == "" {
+			return ""
+		}
+
+		return b.URI + "\x00" + b.Rel
+	})
+
+	// Blocks without an identity are matched in order of appearance
+	// within their type.
+	keyed(func(b *doc.Block) string {
+		if b.ID != "" || b.UUID != "" || b.URI != "" {
+			return ""
+		}
+
+		return "\x00" + b.Type
+	})
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].A < matches[j].A
+	})
+
+	return matches
+}
+
+// stableMatches returns the largest set of matches that have kept
+// their relative order, the remaining matches are considered moved.
+func stableMatches(matches []BlockMatch) map[BlockMatch]bool {
+	// Longest increasing subsequence of B over matches sorted by A.
+	n := len(matches)
+	tails := make([]int, 0, n)
+	prev := make([]int, n)
+
+	for i := range matches {
+		pos := sort.Search(len(tails), func(k int) bool {
+			return matches[tails[k]].B >= matches[i].B
+		})
+
+		if pos > 0 {
+			prev[i] = tails[pos-1]
+		} else {
+			prev[i] = -1
+		}
+
+		if pos == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[pos] = i
+		}
+	}
+
+	stable := make(map[BlockMatch]bool, len(tails))
+
+	if len(tails) == 0 {
+		return stable
+	}
+
+	for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+		stable[matches[i]] = true
+	}
+
+	return stable
+}
@@ -0,0 +1,120 @@
+package navigadoc_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+func TestDiffIdentical(t *testing.T) {
+	a := loadDocument(t, "./testdata/text.json")
+	b := loadDocument(t, "./testdata/text.json")
+
+	changes := navigadoc.Diff(a, b)
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got:\n%s", changes)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := loadDocument(t, "./testdata/text.json")
+	b := loadDocument(t, "./testdata/text.json")
+
+	b.Title = "New title"
+	b.Content[0].Data["text"] = "New header"
+	b.Content[6].Links[0].Data["width"] = "1024"
+	delete(b.Content[6].Links[0].Data, "height")
+	// Swap the two last paragraphs
+	b.Content[4], b.Content[5] = b.Content[5], b.Content[4]
+	b.Links = append(b.Links[:1], b.Links[2:]...)
+	b.Meta = append(b.Meta, doc.Block{Type: "x-im/socialembed", ID: "new"})
+	b.Properties = append(b.Properties, doc.Property{Name: "extra", Value: "1"})
+
+	changes := navigadoc.Diff(a, b)
+
+	expected := []string{
+		`~ title: "Proin eget dignissim ipsum" -> "New title"`,
+		`+ /properties/3: extra="1"`,
+		`~ /content/0 data.text: "Lorem ipsum dolor sit" -> "New header"`,
+		`> /content/5: moved from /content/4`,
+		`- /content/6/links/0 data.height: "2695"`,
+		`~ /content/6/links/0 data.width: "3560" -> "1024"`,
+		`+ /meta/3: x-im/socialembed new`,
+		`- /links/1: x-im/article alternate a0836ecc-1d4a-4ce0-b5dc-7d06ba853759`,
+	}
+
+	got := strings.Split(changes.String(), "\n")
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d changes, got %d:\n%s", len(expected), len(got), changes)
+	}
+
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected change %d to be\n%s\nwas\n%s", i, expected[i], got[i])
+		}
+	}
+
+	_, err := json.Marshal(changes)
+	must(t, err, "could not marshal changes")
+}
+
+func TestDiffRepeatedProperties(t *testing.T) {
+	a := loadDocument(t, "./examples/concept-invalid-xml.json")
+	b := loadDocument(t, "./examples/concept-invalid-xml.json")
+
+	changes := navigadoc.Diff(a, b)
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got:\n%s", changes)
+	}
+
+	a = &doc.Document{Properties: []doc.Property{
+		{Name: "definition", Value: "first"},
+		{Name: "definition", Value: "second"},
+		{Name: "definition", Value: "third"},
+	}}
+	b = &doc.Document{Properties: []doc.Property{
+		{Name: "definition", Value: "first"},
+		{Name: "definition", Value: "changed"},
+	}}
+
+	expected := []string{
+		`~ /properties/1 value: "second" -> "changed"`,
+		`- /properties/2: definition="third"`,
+	}
+
+	got := strings.Split(navigadoc.Diff(a, b).String(), "\n")
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected changes\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestMatchBlocks(t *testing.T) {
+	a := []doc.Block{
+		{ID: "1", Type: "x-im/paragraph"},
+		{UUID: "9E1653F3-7575-4CB7-9B74-DC4DEA63513E", Rel: "author"},
+		{URI: "im://user/1", Rel: "author"},
+		{Type: "x-im/header"},
+	}
+	b := []doc.Block{
+		{Type: "x-im/header"},
+		{URI: "im://user/1", Rel: "author"},
+		{UUID: "9e1653f3-7575-4cb7-9b74-dc4dea63513e", Rel: "author"},
+		{ID: "1", Type: "x-im/preamble"},
+	}
+
+	matches := navigadoc.MatchBlocks(a, b)
+
+	expected := []navigadoc.BlockMatch{{0, 3}, {1, 2}, {2, 1}, {3, 0}}
+	if len(matches) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, matches)
+	}
+
+	for i := range expected {
+		if matches[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], matches[i])
+		}
+	}
+}
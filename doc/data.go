@@ -0,0 +1,348 @@
+package doc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeFormat is the canonical format for timestamps in Block.Data.
+const TimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// ErrMissingData is wrapped by DataError when a key is missing or empty.
+var ErrMissingData = errors.New("missing value")
+
+// DataError is returned when a Block.Data value is missing or can't be
+// parsed as the requested type.
+type DataError struct {
+	// Path is the location of the block, f.ex. a JSON pointer, if the
+	// caller knows it. It falls back to the block type and ID.
+	Path      string
+	BlockType string
+	BlockID   string
+	Key       string
+	Value     string
+	Err       error
+}
+
+func (e *DataError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = e.BlockType
+		if e.BlockID != "" {
+			path += "#" + e.BlockID
+		}
+	}
+
+	if errors.Is(e.Err, ErrMissingData) {
+		return fmt.Sprintf("%s: data %q: %v", path, e.Key, e.Err)
+	}
+
+	return fmt.Sprintf("%s: data %q: invalid value %q: %v", path, e.Key, e.Value, e.Err)
+}
+
+func (e *DataError) Unwrap() error {
+	return e.Err
+}
+
+// WithPath returns a copy of the error with the block path set.
+func (e *DataError) WithPath(path string) *DataError {
+	c := *e
+	c.Path = path
+
+	return &c
+}
+
+// WithDataPath sets the block path, f.ex. Cursor.Path when walking a
+// document, on an error returned by the Block accessors. Set the path
+// before wrapping the error, other errors are returned as is.
+func WithDataPath(err error, path string) error {
+	derr, ok := err.(*DataError)
+	if !ok {
+		return err
+	}
+
+	return derr.WithPath(path)
+}
+
+func (b *Block) dataError(key string, value string, err error) error {
+	return &DataError{
+		BlockType: b.Type,
+		BlockID:   b.ID,
+		Key:       key,
+		Value:     value,
+		Err:       err,
+	}
+}
+
+func (b *Block) dataValue(key string) (string, error) {
+	v := b.Data[key]
+	if v == "" {
+		return "", b.dataError(key, v, ErrMissingData)
+	}
+
+	return v, nil
+}
+
+func (b *Block) setData(key, value string) {
+	if b.Data == nil {
+		b.Data = make(map[string]string)
+	}
+
+	b.Data[key] = value
+}
+
+// ParseInt returns the data value for key as an integer.
+func (b *Block) ParseInt(key string) (int, error) {
+	v, err := b.dataValue(key)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return 0, b.dataError(key, v, errors.New("not an integer"))
+	}
+
+	return i, nil
+}
+
+// Int returns the data value for key as an integer, or def if it's
+// missing or invalid.
+func (b *Block) Int(key string, def int) int {
+	i, err := b.ParseInt(key)
+	if err != nil {
+		return def
+	}
+
+	return i
+}
+
+// SetInt sets the data value for key to an integer.
+func (b *Block) SetInt(key string, value int) {
+	b.setData(key, strconv.Itoa(value))
+}
+
+// ParseFloat returns the data value for key as a float.
+func (b *Block) ParseFloat(key string) (float64, error) {
+	v, err := b.dataValue(key)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, b.dataError(key, v, errors.New("not a number"))
+	}
+
+	return f, nil
+}
+
+// Float returns the data value for key as a float, or def if it's
+// missing or invalid.
+func (b *Block) Float(key string, def float64) float64 {
+	f, err := b.ParseFloat(key)
+	if err != nil {
+		return def
+	}
+
+	return f
+}
+
+// SetFloat sets the data value for key to a float, using the shortest
+// representation that round-trips.
+func (b *Block) SetFloat(key string, value float64) {
+	b.setData(key, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// ParseBool returns the data value for key as a boolean, accepting the
+// values understood by strconv.ParseBool.
+func (b *Block) ParseBool(key string) (bool, error) {
+	v, err := b.dataValue(key)
+	if err != nil {
+		return false, err
+	}
+
+	t, err := strconv.ParseBool(strings.TrimSpace(v))
+	if err != nil {
+		return false, b.dataError(key, v, errors.New("not a boolean"))
+	}
+
+	return t, nil
+}
+
+// Bool returns the data value for key as a boolean, or def if it's
+// missing or invalid.
+func (b *Block) Bool(key string, def bool) bool {
+	t, err := b.ParseBool(key)
+	if err != nil {
+		return def
+	}
+
+	return t
+}
+
+// SetBool sets the data value for key to "true" or "false".
+func (b *Block) SetBool(key string, value bool) {
+	b.setData(key, strconv.FormatBool(value))
+}
+
+// ParseTime returns the data value for key as a time. The value must be
+// an RFC3339 timestamp, with or without fractional seconds.
+func (b *Block) ParseTime(key string) (time.Time, error) {
+	v, err := b.dataValue(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(v))
+	if err != nil {
+		return time.Time{}, b.dataError(key, v, errors.New("not an RFC3339 timestamp"))
+	}
+
+	return t, nil
+}
+
+// Time returns the data value for key as a time, or def if it's missing
+// or invalid.
+func (b *Block) Time(key string, def time.Time) time.Time {
+	t, err := b.ParseTime(key)
+	if err != nil {
+		return def
+	}
+
+	return t
+}
+
+// SetTime sets the data value for key to a timestamp in TimeFormat,
+// f.ex. "2020-02-25T06:30:00.000Z". The UTC offset of the value is kept.
+func (b *Block) SetTime(key string, value time.Time) {
+	b.setData(key, value.Format(TimeFormat))
+}
+
+var isoDurationExp = regexp.MustCompile(
+	`^P(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseDuration returns the data value for key as a duration. The value
+// can be a number of seconds, f.ex. "3600", an ISO 8601 duration
+// without years and months, f.ex. "PT6H", or a Go duration, f.ex.
+// "1h30m".
+func (b *Block) ParseDuration(key string) (time.Duration, error) {
+	v, err := b.dataValue(key)
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := parseDuration(strings.TrimSpace(v))
+	if err != nil {
+		return 0, b.dataError(key, v, err)
+	}
+
+	return d, nil
+}
+
+func parseDuration(v string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+		return toDuration(seconds * float64(time.Second))
+	}
+
+	if m := isoDurationExp.FindStringSubmatch(v); m != nil && v != "P" && v != "PT" {
+		units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+		var d float64
+
+		for i, unit := range units {
+			if m[i+1] == "" {
+				continue
+			}
+
+			n, err := strconv.ParseFloat(m[i+1], 64)
+			if err != nil {
+				return 0, errors.New("not a duration")
+			}
+
+			d += n * float64(unit)
+		}
+
+		return toDuration(d)
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, errors.New("not a duration")
+	}
+
+	return d, nil
+}
+
+// toDuration converts nanoseconds to a duration, rejecting values that
+// aren't finite or are out of range for a time.Duration.
+func toDuration(ns float64) (time.Duration, error) {
+	if math.IsNaN(ns) || math.IsInf(ns, 0) || ns >= math.MaxInt64 || ns < math.MinInt64 {
+		return 0, errors.New("not a duration in range")
+	}
+
+	return time.Duration(ns), nil
+}
+
+// Duration returns the data value for key as a duration, or def if it's
+// missing or invalid.
+func (b *Block) Duration(key string, def time.Duration) time.Duration {
+	d, err := b.ParseDuration(key)
+	if err != nil {
+		return def
+	}
+
+	return d
+}
+
+// SetDuration sets the data value for key to a number of seconds,
+// f.ex. "3600" or "0.5".
+func (b *Block) SetDuration(key string, value time.Duration) {
+	b.setData(key, strconv.FormatFloat(value.Seconds(), 'f', -1, 64))
+}
+
+// ParseEnum returns the data value for key if it's one of the allowed
+// values.
+func (b *Block) ParseEnum(key string, allowed ...string) (string, error) {
+	v, err := b.dataValue(key)
+	if err != nil {
+		return "", err
+	}
+
+	for _, a := range allowed {
+		if strings.TrimSpace(v) == a {
+			return a, nil
+		}
+	}
+
+	return "", b.dataError(key, v, fmt.Errorf("not one of %s", strings.Join(allowed, ", ")))
+}
+
+// Enum returns the data value for key if it's one of the allowed values,
+// or def otherwise.
+func (b *Block) Enum(key string, def string, allowed ...string) string {
+	v, err := b.ParseEnum(key, allowed...)
+	if err != nil {
+		return def
+	}
+
+	return v
+}
+
+// SetEnum sets the data value for key, the value must be one of the
+// allowed values.
+func (b *Block) SetEnum(key string, value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			b.setData(key, value)
+			return nil
+		}
+	}
+
+	return b.dataError(key, value, fmt.Errorf("not one of %s", strings.Join(allowed, ", ")))
+}
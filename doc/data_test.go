@@ -0,0 +1,195 @@
+package doc_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+func TestDataGetters(t *testing.T) {
+	block := doc.Block{
+		ID:   "m1",
+		Type: "x-im/newscoverage",
+		Data: map[string]string{
+			"width":    "1536",
+			"ratio":    "1.5",
+			"private":  "true",
+			"start":    "2020-02-25T06:30:00.000Z",
+			"end":      "2016-01-31T10:00:00+01:00",
+			"duration": "3600",
+			"text":     "PT6H",
+			"gotime":   "1h30m",
+			"priority": "2",
+			"format":   "lifetimecode",
+			"broken":   "abc",
+		},
+	}
+
+	if v := block.Int("width", 0); v != 1536 {
+		t.Errorf("expected width 1536, was %d", v)
+	}
+
+	if v := block.Float("ratio", 0); v != 1.5 {
+		t.Errorf("expected ratio 1.5, was %f", v)
+	}
+
+	if v := block.Bool("private", false); !v {
+		t.Error("expected private to be true")
+	}
+
+	start := block.Time("start", time.Time{})
+	if !start.Equal(time.Date(2020, 2, 25, 6, 30, 0, 0, time.UTC)) {
+		t.Errorf("unexpected start %v", start)
+	}
+
+	end := block.Time("end", time.Time{})
+	if !end.Equal(time.Date(2016, 1, 31, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected end %v", end)
+	}
+
+	durations := map[string]time.Duration{
+		"duration": time.Hour,
+		"text":     6 * time.Hour,
+		"gotime":   90 * time.Minute,
+	}
+
+	for key, expected := range durations {
+		if d := block.Duration(key, 0); d != expected {
+			t.Errorf("expected %s to be %v, was %v", key, expected, d)
+		}
+	}
+
+	if v := block.Enum("format", "", "lifetimecode", "timecode"); v != "lifetimecode" {
+		t.Errorf("expected format lifetimecode, was %q", v)
+	}
+
+	if v := block.Int("broken", 7); v != 7 {
+		t.Errorf("expected default for invalid value, was %d", v)
+	}
+
+	if v := block.Int("missing", 7); v != 7 {
+		t.Errorf("expected default for missing value, was %d", v)
+	}
+}
+
+func TestDataErrors(t *testing.T) {
+	block := doc.Block{
+		ID:   "m1",
+		Type: "x-im/image",
+		Data: map[string]string{"width": "wide"},
+	}
+
+	_, err := block.ParseInt("width")
+
+	var derr *doc.DataError
+	if !errors.As(err, &derr) {
+		t.Fatalf("expected a *DataError, got %v", err)
+	}
+
+	if derr.Key != "width" || derr.BlockID != "m1" || derr.Value != "wide" {
+		t.Errorf("unexpected error details %+v", derr)
+	}
+
+	if !strings.Contains(err.Error(), "x-im/image#m1") || !strings.Contains(err.Error(), `"width"`) {
+		t.Errorf("expected error to name block and key, was %q", err.Error())
+	}
+
+	_, err = block.ParseTime("start")
+	if !errors.Is(err, doc.ErrMissingData) {
+		t.Errorf("expected ErrMissingData, got %v", err)
+	}
+
+	_, err = block.ParseEnum("width", "narrow")
+	if err == nil {
+		t.Error("expected an error for a value outside the enum")
+	}
+
+	block.Data["format"] = " timecode "
+
+	if v, err := block.ParseEnum("format", "lifetimecode", "timecode"); err != nil || v != "timecode" {
+		t.Errorf("expected the enum value to be trimmed, got %q %v", v, err)
+	}
+
+	for _, v := range []string{"NaN", "Inf", "-Inf", "1e300", "PT99999999999999H"} {
+		block.Data["duration"] = v
+
+		if d, err := block.ParseDuration("duration"); err == nil {
+			t.Errorf("expected an error for the duration %q, got %v", v, d)
+		}
+	}
+}
+
+func TestDataErrorPath(t *testing.T) {
+	block := doc.Block{
+		ID:   "m1",
+		Type: "x-im/image",
+		Data: map[string]string{"width": "wide"},
+	}
+
+	_, err := block.ParseInt("width")
+	err = fmt.Errorf("image: %w", doc.WithDataPath(err, "/meta/0"))
+
+	expected := `image: /meta/0: data "width": invalid value "wide": not an integer`
+	if err.Error() != expected {
+		t.Errorf("expected %q, was %q", expected, err.Error())
+	}
+
+	var derr *doc.DataError
+	if !errors.As(err, &derr) || derr.Path != "/meta/0" || derr.BlockID != "m1" {
+		t.Errorf("expected the path to be set on the *DataError, got %v", err)
+	}
+
+	moved := derr.WithPath("/meta/1")
+	if moved.Path != "/meta/1" || derr.Path != "/meta/0" {
+		t.Errorf("expected WithPath to return a copy, got %q and %q", moved.Path, derr.Path)
+	}
+
+	other := errors.New("other")
+	if doc.WithDataPath(other, "/meta/0") != other {
+		t.Error("expected other errors to be returned as is")
+	}
+
+	if doc.WithDataPath(nil, "/meta/0") != nil {
+		t.Error("expected nil to be returned as is")
+	}
+}
+
+func TestDataSetters(t *testing.T) {
+	var block doc.Block
+
+	block.SetInt("width", 1536)
+	block.SetFloat("ratio", 0.25)
+	block.SetBool("private", false)
+	block.SetTime("start", time.Date(2020, 2, 25, 6, 30, 0, 0, time.UTC))
+	block.SetTime("end", time.Date(2016, 1, 31, 10, 0, 0, 0, time.FixedZone("", 3600)))
+	block.SetDuration("duration", time.Hour)
+
+	err := block.SetEnum("format", "lifetimecode", "lifetimecode", "timecode")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if block.SetEnum("format", "other", "lifetimecode") == nil {
+		t.Error("expected an error for a value outside the enum")
+	}
+
+	expected := map[string]string{
+		"width":    "1536",
+		"ratio":    "0.25",
+		"private":  "false",
+		"start":    "2020-02-25T06:30:00.000Z",
+		"end":      "2016-01-31T10:00:00.000+01:00",
+		"duration": "3600",
+		"format":   "lifetimecode",
+	}
+
+	for key, value := range expected {
+		if block.Data[key] != value {
+			t.Errorf("expected %s to be %q, was %q", key, value, block.Data[key])
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package doc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/testutil"
+)
+
+func TestDeepCopy(t *testing.T) {
+	original := testutil.LoadDocument(t, "../testdata/text.json")
+	c := original.DeepCopy()
+
+	if !c.Equal(original) {
+		t.Fatal("expected copy to be equal to the original")
+	}
+
+	c.Meta[0].Data["score"] = "changed"
+	c.Links[0].Title = "changed"
+	c.Properties[0].Value = "changed"
+	c.Products[0] = "changed"
+	*c.Created = c.Created.Add(time.Hour)
+
+	if original.Meta[0].Data["score"] == "changed" ||
+		original.Links[0].Title == "changed" ||
+		original.Properties[0].Value == "changed" ||
+		original.Products[0] == "changed" ||
+		original.Created.Equal(*c.Created) {
+		t.Error("expected copy to not share data with the original")
+	}
+
+	if c.Equal(original) {
+		t.Error("expected modified copy to differ")
+	}
+
+	var nilDoc *doc.Document
+	if nilDoc.DeepCopy() != nil {
+		t.Error("expected nil copy of nil document")
+	}
+}
+
+func TestEqualNilAndEmpty(t *testing.T) {
+	a := &doc.Document{Links: []doc.Block{{Rel: "a"}}}
+	b := &doc.Document{Links: []doc.Block{{Rel: "a", Data: map[string]string{}}}}
+
+	if !a.DeepCopy().Equal(a) || b.DeepCopy().Links[0].Data == nil {
+		t.Error("expected copies to keep nil and empty maps apart")
+	}
+
+	if a.Equal(b) {
+		t.Error("expected nil and empty data to differ by default")
+	}
+
+	if !a.Equal(b, doc.NilEqualsEmpty()) {
+		t.Error("expected nil and empty data to be equal with NilEqualsEmpty")
+	}
+}
+
+func TestEqualIgnore(t *testing.T) {
+	a := testutil.LoadDocument(t, "../testdata/text.json")
+	b := a.DeepCopy()
+
+	modified := b.Modified.Add(time.Minute)
+	b.Modified = &modified
+
+	if a.Equal(b) {
+		t.Error("expected different modified times to differ")
+	}
+
+	if !a.Equal(b, doc.IgnoreTimestamps()) {
+		t.Error("expected timestamps to be ignored")
+	}
+
+	b.Content[0].ID = "other"
+
+	if a.Equal(b, doc.IgnoreTimestamps()) {
+		t.Error("expected different block IDs to differ")
+	}
+
+	if !a.Equal(b, doc.IgnoreTimestamps(), doc.IgnoreFields("Block.ID")) {
+		t.Error("expected block IDs to be ignored")
+	}
+
+	inZone := a.Created.In(time.FixedZone("", 3600))
+	b.Created = &inZone
+
+	if !a.Equal(b, doc.IgnoreFields("Modified", "ID")) {
+		t.Error("expected the same instant in another zone to be equal")
+	}
+}
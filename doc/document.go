@@ -1,10 +1,13 @@
-// Code generated by doc-generator. DO NOT EDIT.
+// Code generated by protoc-gen-navigadoc. DO NOT EDIT.
+
 package doc
 
 import (
-	"time"
+	time "time"
 )
 
+// Document is a NavigaDoc document, the content together with its
+// metadata and links.
 type Document struct {
 	// UUID is a unique ID for the document, this can be a random v4
 	// UUID, or a URI-derived v5 UUID.
@@ -66,11 +69,17 @@ type Document struct {
 	Unpublished *time.Time `json:"unpublished,omitempty"`
 	Provider    string     `json:"provider,omitempty"`
 }
+
+// Property is a key-value pair
 type Property struct {
 	Name       string            `json:"name,omitempty"`
 	Value      string            `json:"value,omitempty"`
 	Parameters map[string]string `json:"parameters,omitempty"`
 }
+
+// Block is the building block for data embedded in documents. It is
+// used for both content, links and metadata. Blocks have can be
+// nested, but that's nothing to strive for, keep it simple.
 type Block struct {
 	// ID is the block ID
 	ID string `json:"id,omitempty"`
@@ -1,5 +1,12 @@
 package navigadoc
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
 type MalformedDocumentError struct {
 	err string
 }
@@ -55,3 +62,163 @@ var (
 	ErrEmptyPackage      = &MalformedDocumentError{"empty list package"}
 	ErrUnsupportedType   = &MalformedDocumentError{"unsuported type"}
 )
+
+// Severity tells how serious a validation error is.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ErrorCode is a machine-readable identifier for a validation error.
+type ErrorCode string
+
+const (
+	CodeEmptyDocument ErrorCode = "empty_document"
+	CodeEmptyBlock    ErrorCode = "empty_block"
+	CodeInvalidUUID   ErrorCode = "invalid_uuid"
+	// CodeSchema is used for schema errors that don't map to a more
+	// specific code, schema errors otherwise use the codes
+	// "schema_<type>", f.ex. "schema_required".
+	CodeSchema ErrorCode = "schema"
+)
+
+// ValidationError is a single problem found in a document.
+type ValidationError struct {
+	// Pointer is a JSON pointer to the offending value, f.ex.
+	// "/content/1/links/0/uuid". The empty string points to the
+	// document itself.
+	Pointer  string
+	Code     ErrorCode
+	Severity Severity
+	// BlockType and BlockID identify the closest block containing the
+	// offending value, if any.
+	BlockType string
+	BlockID   string
+	Err       error
+}
+
+func (e *ValidationError) Error() string {
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+
+	return fmt.Sprintf("%s: %v", pointer, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON encodes the error with the cause as a message.
+func (e *ValidationError) MarshalJSON() ([]byte, error) {
+	v := struct {
+		Pointer   string    `json:"pointer"`
+		Code      ErrorCode `json:"code"`
+		Severity  Severity  `json:"severity"`
+		BlockType string    `json:"blockType,omitempty"`
+		BlockID   string    `json:"blockId,omitempty"`
+		Message   string    `json:"message"`
+	}{
+		Pointer:   e.Pointer,
+		Code:      e.Code,
+		Severity:  e.Severity,
+		BlockType: e.BlockType,
+		BlockID:   e.BlockID,
+	}
+
+	if e.Err != nil {
+		v.Message = e.Err.Error()
+	}
+
+	return json.Marshal(v)
+}
+
+// ValidationErrors is a list of validation errors, use errors.As to get
+// hold of it from an error returned by a validator.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i := range e {
+		messages[i] = e[i].Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Is reports whether any of the errors matches target.
+func (e ValidationErrors) Is(target error) bool {
+	for i := range e {
+		if errors.Is(e[i], target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As sets a **ValidationError target to the first error.
+func (e ValidationErrors) As(target interface{}) bool {
+	t, ok := target.(**ValidationError)
+	if !ok || len(e) == 0 {
+		return false
+	}
+
+	*t = e[0]
+
+	return true
+}
+
+// Severe returns the errors that have SeverityError.
+func (e ValidationErrors) Severe() ValidationErrors {
+	var severe ValidationErrors
+
+	for i := range e {
+		if e[i].Severity == SeverityError {
+			severe = append(severe, e[i])
+		}
+	}
+
+	return severe
+}
+
+// Err returns nil if the list is empty, the list otherwise.
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+func (e ValidationErrors) list() []error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(e))
+	for i := range e {
+		errs[i] = e[i]
+	}
+
+	return errs
+}
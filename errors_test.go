@@ -0,0 +1,148 @@
+package navigadoc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+func TestCheckForEmptyBlocksPath(t *testing.T) {
+	document := &doc.Document{
+		Type: "x-im/article",
+		Content: []doc.Block{
+			{Type: "x-im/paragraph"},
+			{Type: "x-im/content-part", Content: []doc.Block{
+				{Type: "x-im/paragraph"},
+				{},
+			}},
+		},
+	}
+
+	err := navigadoc.CheckForEmptyBlocks(document)
+	if !errors.Is(err, navigadoc.ErrEmptyBlock) {
+		t.Fatalf("expected ErrEmptyBlock, got %v", err)
+	}
+
+	if !strings.HasSuffix(err.Error(), ": content/1/content/1") {
+		t.Errorf("expected the path content/1/content/1, was %q", err.Error())
+	}
+
+	if navigadoc.CheckForEmptyBlocks(&doc.Document{}) != navigadoc.ErrEmptyDoc {
+		t.Error("expected ErrEmptyDoc for an empty document")
+	}
+
+	// ValidateBlocks reports the same block with a JSON pointer.
+	var verr *navigadoc.ValidationError
+	if !errors.As(navigadoc.ValidateBlocks(document), &verr) {
+		t.Fatal("expected a *ValidationError")
+	}
+
+	if verr.Pointer != "/content/1/content/1" || verr.Code != navigadoc.CodeEmptyBlock {
+		t.Errorf("expected an empty block at /content/1/content/1, was %s %s", verr.Code, verr.Pointer)
+	}
+}
+
+func TestValidateBlocks(t *testing.T) {
+	document := loadDocument(t, "./testdata/uuids-invalid.json")
+	document.Meta = append(document.Meta, doc.Block{})
+
+	err := navigadoc.ValidateBlocks(document)
+
+	var verrs navigadoc.ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+
+	codes := make(map[navigadoc.ErrorCode]int)
+
+	for _, e := range verrs {
+		codes[e.Code]++
+
+		if e.Code == navigadoc.CodeInvalidUUID && !strings.HasSuffix(e.Pointer, "/uuid") {
+			t.Errorf("expected uuid pointer, was %s", e.Pointer)
+		}
+	}
+
+	if codes[navigadoc.CodeEmptyBlock] != 1 {
+		t.Errorf("expected one empty block error, got %v", verrs)
+	}
+
+	if codes[navigadoc.CodeInvalidUUID] == 0 {
+		t.Errorf("expected invalid uuid errors, got %v", verrs)
+	}
+
+	if !errors.Is(err, navigadoc.InvalidArgumentError{}) {
+		t.Error("expected errors to match InvalidArgumentError")
+	}
+
+	var first *navigadoc.ValidationError
+	if !errors.As(err, &first) || first != verrs[0] {
+		t.Error("expected errors.As to yield the first error")
+	}
+
+	must(t, navigadoc.ValidateBlocks(loadDocument(t, "./testdata/text.json")), "valid document")
+}
+
+func TestValidateNavigadocJSONErrors(t *testing.T) {
+	document := `{
+		"type": "x-im/article",
+		"links": [
+			{"type": "x-im/author", "rel": "author"},
+			{"id": "b1", "type": "x-im/category", "rel": "subject", "uuid": "not-a-uuid"}
+		],
+		"properties": [{"value": "nameless"}]
+	}`
+
+	errs, err := navigadoc.ValidateNavigadocJSON(document)
+	must(t, err, "failed to validate")
+
+	pointers := make(map[string]*navigadoc.ValidationError)
+	messages := make(map[string]bool)
+
+	for _, e := range errs {
+		var verr *navigadoc.ValidationError
+		if !errors.As(e, &verr) {
+			t.Fatalf("expected *ValidationError, got %T", e)
+		}
+
+		pointers[verr.Pointer] = verr
+		messages[e.Error()] = true
+	}
+
+	// The error text is the same as before the errors were structured.
+	if !messages["(root): uuid is required"] {
+		t.Errorf("expected the error text \"(root): uuid is required\", got %v", errs)
+	}
+
+	uuidErr, ok := pointers["/links/1/uuid"]
+	if !ok {
+		t.Fatalf("expected error for /links/1/uuid, got %v", errs)
+	}
+
+	if uuidErr.BlockType != "x-im/category" || uuidErr.BlockID != "b1" {
+		t.Errorf("expected block x-im/category b1, was %s %s", uuidErr.BlockType, uuidErr.BlockID)
+	}
+
+	nameErr, ok := pointers["/properties/0/name"]
+	if !ok {
+		t.Fatalf("expected error for /properties/0/name, got %v", errs)
+	}
+
+	if nameErr.Code != "schema_required" {
+		t.Errorf("expected code schema_required, was %s", nameErr.Code)
+	}
+
+	data, err := json.Marshal(nameErr)
+	must(t, err, "failed to marshal error")
+
+	var encoded map[string]interface{}
+	must(t, json.Unmarshal(data, &encoded), "failed to unmarshal error")
+
+	if encoded["pointer"] != "/properties/0/name" || encoded["severity"] != "error" {
+		t.Errorf("unexpected JSON encoding %s", data)
+	}
+}
@@ -17,6 +17,13 @@ import (
 // NavigaDocSchema embedded schemas
 var NavigaDocSchema string
 
+//go:embed json/cca-schema.json
+// CCASchema is the stricter document contract used by CCA
+var CCASchema string
+
+// CheckForEmptyBlocks returns ErrEmptyDoc for an empty document, or
+// ErrEmptyBlock wrapped with the path of the first empty block. Use
+// ValidateBlocks to get every problem as a *ValidationError.
 func CheckForEmptyBlocks(document *doc.Document) error {
 	var err error
 
@@ -46,6 +53,9 @@ func CheckForEmptyBlocks(document *doc.Document) error {
 	return nil
 }
 
+// CheckForEmptyBlocksRecursive returns ErrEmptyBlock wrapped with the
+// path of the first empty block in block and its children. The path is
+// the list of tokens leading up to the list that block belongs to.
 func CheckForEmptyBlocksRecursive(block doc.Block, kind string, idx int, path []string) error {
 	path = append(path, kind, strconv.Itoa(idx))
 
@@ -69,10 +79,58 @@ func CheckForEmptyBlocksRecursive(block doc.Block, kind string, idx int, path []
 		}
 	}
 	for i, block := range block.Content {
-		err = CheckForEmptyBlocksRecursive(block, "content,", i, path)
+		err = CheckForEmptyBlocksRecursive(block, "content", i, path)
 		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// ValidateBlocks checks all blocks in the document for emptiness and
+// invalid UUIDs. The returned error is a ValidationErrors listing every
+// problem found.
+func ValidateBlocks(document *doc.Document) error {
+	if document == nil || reflect.ValueOf(*document).IsZero() {
+		return ValidationErrors{{
+			Code: CodeEmptyDocument,
+			Err:  ErrEmptyDoc,
+		}}
+	}
+
+	var errs ValidationErrors
+
+	if err := ValidateUUID(document.UUID); err != nil {
+		errs = append(errs, &ValidationError{
+			Pointer: "/uuid",
+			Code:    CodeInvalidUUID,
+			Err:     InvalidArgumentError{Msg: err.Error(), Err: err},
+		})
+	}
+
+	_ = Walk(document, func(block *doc.Block, cursor *Cursor) (WalkAction, error) {
+		if reflect.ValueOf(*block).IsZero() {
+			errs = append(errs, &ValidationError{
+				Pointer: cursor.Path,
+				Code:    CodeEmptyBlock,
+				Err:     ErrEmptyBlock,
+			})
+
+			return WalkSkipChildren, nil
+		}
+
+		if err := ValidateUUID(block.UUID); err != nil {
+			errs = append(errs, &ValidationError{
+				Pointer:   cursor.Path + "/uuid",
+				Code:      CodeInvalidUUID,
+				BlockType: block.Type,
+				BlockID:   block.ID,
+				Err:       InvalidArgumentError{Msg: err.Error(), Err: err},
+			})
+		}
+
+		return WalkContinue, nil
+	})
+
+	return errs.Err()
+}
@@ -1 +1 @@
-go run cmd/codegen/main.go
\ No newline at end of file
+make generate
@@ -1,14 +1,22 @@
 module github.com/navigacontentlab/navigadoc
 
-go 1.17
+go 1.21
 
 require (
+	github.com/bufbuild/protocompile v0.14.1
 	github.com/google/uuid v1.2.0
 	github.com/xeipuuv/gojsonschema v1.2.0
-	google.golang.org/protobuf v1.28.0
+	google.golang.org/grpc v1.47.0
+	google.golang.org/protobuf v1.34.2
 )
 
 require (
+	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/xeipuuv/gojsonpointer v0.0.0-20180127040702-4e3ac2762d5f // indirect
 	github.com/xeipuuv/gojsonreference v0.0.0-20180127040603-bd5ef7bd5415 // indirect
+	golang.org/x/net v0.0.0-20201021035429-f5854403a974 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	golang.org/x/sys v0.0.0-20210119212857-b64e53b001e4 // indirect
+	golang.org/x/text v0.3.3 // indirect
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013 // indirect
 )
@@ -3,7 +3,7 @@ package navigadoc
 import (
 	"errors"
 	"fmt"
-
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
@@ -112,24 +112,129 @@ func ValidateUUID(theUUID string) error {
 }
 
 // ValidateNavigadocJSON validates the Navigadoc against a JSON Schema
-// The error array contains schema issues
+// The error array contains schema issues, they keep the gojsonschema
+// error text and unwrap to *ValidationError
 // A non-nil error indicates an error with the validator
 func ValidateNavigadocJSON(document string) ([]error, error) {
-	schemaLoader := gojsonschema.NewStringLoader(NavigaDocSchema)
-	documentLoader := gojsonschema.NewStringLoader(document)
+	v, err := NewValidator()
+	if err != nil {
+		return nil, err
+	}
 
-	result, err := gojsonschema.Validate(schemaLoader, documentLoader)
+	verrs, err := v.ValidateBytes([]byte(document))
 	if err != nil {
 		return nil, err
 	}
 
 	var errs []error
-	if result.Valid() {
-		return nil, nil
+	for _, e := range verrs {
+		errs = append(errs, legacySchemaError{e})
 	}
 
-	for _, e := range result.Errors() {
-		errs = append(errs, errors.New(e.String()))
-	}
 	return errs, nil
 }
+
+// schemaError is the cause of a schema violation.
+type schemaError struct {
+	description string
+	// text is the gojsonschema error text, f.ex. "(root): uuid is
+	// required".
+	text string
+}
+
+func (e schemaError) Error() string {
+	return e.description
+}
+
+// legacySchemaError is the error text that ValidateNavigadocJSON has
+// always returned for a *ValidationError.
+type legacySchemaError struct {
+	*ValidationError
+}
+
+func (e legacySchemaError) Error() string {
+	var serr schemaError
+	if errors.As(e.ValidationError.Err, &serr) {
+		return serr.text
+	}
+
+	return e.ValidationError.Error()
+}
+
+func (e legacySchemaError) Unwrap() error {
+	return e.ValidationError
+}
+
+// schemaValidationErrors converts gojsonschema errors to validation
+// errors, root is the decoded document and is used to look up the
+// blocks that the errors belong to.
+func schemaValidationErrors(errs []gojsonschema.ResultError, root interface{}) ValidationErrors {
+	result := make(ValidationErrors, len(errs))
+
+	for i, e := range errs {
+		pointer := schemaContextPointer(e.Context())
+		blockType, blockID := blockAtPointer(root, pointer)
+
+		if prop, ok := e.Details()["property"].(string); ok && e.Type() == "required" {
+			pointer += "/" + escapePointerToken(prop)
+		}
+
+		code := CodeSchema
+		if e.Type() != "" {
+			code = ErrorCode("schema_" + e.Type())
+		}
+
+		result[i] = &ValidationError{
+			Pointer:   pointer,
+			Code:      code,
+			BlockType: blockType,
+			BlockID:   blockID,
+			Err:       schemaError{description: e.Description(), text: e.String()},
+		}
+	}
+
+	return result
+}
+
+// blockAtPointer returns the type and ID of the innermost block that
+// contains the value at pointer in a decoded document.
+func blockAtPointer(root interface{}, pointer string) (string, string) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return "", ""
+	}
+
+	var blockType, blockID string
+
+	node := root
+
+	for i, token := range tokens {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			node = n[token]
+		case []interface{}:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(n) {
+				return blockType, blockID
+			}
+
+			node = n[idx]
+
+			if i == 0 {
+				break
+			}
+
+			switch Section(tokens[i-1]) {
+			case SectionContent, SectionMeta, SectionLinks:
+				if block, ok := node.(map[string]interface{}); ok {
+					blockType, _ = block["type"].(string)
+					blockID, _ = block["id"].(string)
+				}
+			}
+		default:
+			return blockType, blockID
+		}
+	}
+
+	return blockType, blockID
+}
@@ -0,0 +1,33 @@
+// Package testutil contains helpers shared by the package tests.
+package testutil
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// Must fails the test if err isn't nil.
+func Must(t *testing.T, err error, msg string) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("%s: %v", msg, err)
+	}
+}
+
+// LoadDocument reads and unmarshals a JSON document.
+func LoadDocument(t *testing.T, path string) *doc.Document {
+	t.Helper()
+
+	testData, err := ioutil.ReadFile(path)
+	Must(t, err, "could not open testfile")
+
+	document := &doc.Document{}
+	err = json.Unmarshal(testData, document)
+	Must(t, err, "could not unmarshal doc")
+
+	return document
+}
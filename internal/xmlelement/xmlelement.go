@@ -0,0 +1,172 @@
+// Package xmlelement contains the generic XML element and the block
+// field table shared by the XML based document converters.
+package xmlelement
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// Element is a generic XML element used both to build and to read XML
+// documents.
+type Element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Text     string     `xml:",chardata"`
+	Inner    string     `xml:",innerxml"`
+	Children []*Element `xml:",any"`
+}
+
+// New creates an element, nil children are skipped.
+func New(name string, children ...*Element) *Element {
+	e := &Element{XMLName: xml.Name{Local: name}}
+
+	return e.Add(children...)
+}
+
+// NewText creates an element with text content.
+func NewText(name, text string) *Element {
+	e := New(name)
+	e.Text = text
+
+	return e
+}
+
+// SetAttr adds an attribute, empty values are skipped.
+func (e *Element) SetAttr(name, value string) *Element {
+	if value != "" {
+		e.Attrs = append(e.Attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	}
+
+	return e
+}
+
+// Add appends child elements, nil children are skipped.
+func (e *Element) Add(children ...*Element) *Element {
+	for _, c := range children {
+		if c != nil {
+			e.Children = append(e.Children, c)
+		}
+	}
+
+	return e
+}
+
+// Attr returns the value of an attribute without a namespace.
+func (e *Element) Attr(name string) string {
+	return e.AttrNS("", name)
+}
+
+// AttrNS returns the value of an attribute in a namespace.
+func (e *Element) AttrNS(space, name string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Space == space && a.Name.Local == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// Child returns the first child with the given local name.
+func (e *Element) Child(local string) *Element {
+	for _, c := range e.Children {
+		if c.XMLName.Local == local {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// ChildText returns the text of the first child with the given local
+// name.
+func (e *Element) ChildText(local string) string {
+	c := e.Child(local)
+	if c == nil {
+		return ""
+	}
+
+	return c.Text
+}
+
+// Content returns the text of an element, or the inner XML if the
+// element has child elements.
+func (e *Element) Content() string {
+	if len(e.Children) > 0 {
+		return e.Inner
+	}
+
+	return e.Text
+}
+
+// IsName checks if a name can be used for an element or attribute
+// without a namespace prefix.
+func IsName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsWellFormed checks if the text can be embedded as XML content.
+func IsWellFormed(text string) bool {
+	dec := xml.NewDecoder(strings.NewReader("<x>" + text + "</x>"))
+
+	for {
+		_, err := dec.Token()
+		if err != nil {
+			return errors.Is(err, io.EOF)
+		}
+	}
+}
+
+// SortedKeys returns the keys of a map in sorted order, used to get a
+// stable attribute and element order.
+func SortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// BlockField is a block attribute that is written as an XML attribute.
+type BlockField struct {
+	Name string
+	Get  func(b *doc.Block) *string
+}
+
+// BlockFields are the block attributes, named like in the JSON format.
+var BlockFields = []BlockField{
+	{"id", func(b *doc.Block) *string { return &b.ID }},
+	{"uuid", func(b *doc.Block) *string { return &b.UUID }},
+	{"uri", func(b *doc.Block) *string { return &b.URI }},
+	{"url", func(b *doc.Block) *string { return &b.URL }},
+	{"type", func(b *doc.Block) *string { return &b.Type }},
+	{"title", func(b *doc.Block) *string { return &b.Title }},
+	{"rel", func(b *doc.Block) *string { return &b.Rel }},
+	{"name", func(b *doc.Block) *string { return &b.Name }},
+	{"value", func(b *doc.Block) *string { return &b.Value }},
+	{"contentType", func(b *doc.Block) *string { return &b.ContentType }},
+	{"role", func(b *doc.Block) *string { return &b.Role }},
+}
@@ -0,0 +1,587 @@
+package navigadoc
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// Conflict is a change made on both sides of a three-way merge that
+// couldn't be reconciled. The merged document keeps the value from
+// ours, or the modified block or property when one side deleted it.
+type Conflict struct {
+	// Path is a JSON pointer to the conflicting element in the merged
+	// document.
+	Path string `json:"path"`
+	// Field is the conflicting field, f.ex. "title" or "data.width".
+	// It's empty when the conflict concerns a whole block or property.
+	Field   string      `json:"field,omitempty"`
+	Message string      `json:"message"`
+	Base    interface{} `json:"base,omitempty"`
+	Ours    interface{} `json:"ours,omitempty"`
+	Theirs  interface{} `json:"theirs,omitempty"`
+}
+
+const (
+	conflictBothModified   = "modified on both sides"
+	conflictBothAdded      = "added on both sides with different values"
+	conflictDeletedOurs    = "deleted in ours, modified in theirs"
+	conflictDeletedTheirs  = "modified in ours, deleted in theirs"
+	conflictTimeModified   = "timestamp modified on both sides"
+	conflictProductsChange = "products modified on both sides"
+)
+
+// Merge3 merges the changes made in ours and theirs since base.
+//
+// Header fields, block fields and Data values are merged field by
+// field. Properties are matched by name like MergeProperties does,
+// except that repeated names aren't collapsed: they are paired with an
+// identical base property first and in the order they occur otherwise.
+// Blocks are matched within their list like MatchBlocks does. Block
+// order follows ours, blocks added in theirs are placed after the
+// block that precedes them in theirs.
+//
+// Changes that can't be reconciled are returned as conflicts instead
+// of being silently overwritten.
+func Merge3(base, ours, theirs *doc.Document) (*doc.Document, []Conflict) {
+	if base == nil {
+		base = &doc.Document{}
+	}
+
+	if ours == nil {
+		ours = &doc.Document{}
+	}
+
+	if theirs == nil {
+		theirs = &doc.Document{}
+	}
+
+	m := merger{}
+	result := doc.Document{
+		UUID:     m.mergeString("", "uuid", base.UUID, ours.UUID, theirs.UUID),
+		Type:     m.mergeString("", "type", base.Type, ours.Type, theirs.Type),
+		URI:      m.mergeString("", "uri", base.URI, ours.URI, theirs.URI),
+		URL:      m.mergeString("", "url", base.URL, ours.URL, theirs.URL),
+		Title:    m.mergeString("", "title", base.Title, ours.Title, theirs.Title),
+		Path:     m.mergeString("", "path", base.Path, ours.Path, theirs.Path),
+		Products: m.mergeProducts(base.Products, ours.Products, theirs.Products),
+		Source:   m.mergeString("", "source", base.Source, ours.Source, theirs.Source),
+		Language: m.mergeString("", "language", base.Language, ours.Language, theirs.Language),
+		Status:   m.mergeString("", "status", base.Status, ours.Status, theirs.Status),
+		Provider: m.mergeString("", "provider", base.Provider, ours.Provider, theirs.Provider),
+	}
+
+	result.Created = m.mergeTime("created", base.Created, ours.Created, theirs.Created)
+	result.Modified = m.mergeTime("modified", base.Modified, ours.Modified, theirs.Modified)
+	result.Published = m.mergeTime("published", base.Published, ours.Published, theirs.Published)
+	result.Unpublished = m.mergeTime("unpublished", base.Unpublished, ours.Unpublished, theirs.Unpublished)
+
+	result.Properties = m.mergeProperties(base.Properties, ours.Properties, theirs.Properties)
+	result.Content = m.mergeBlockList("", SectionContent, base.Content, ours.Content, theirs.Content)
+	result.Meta = m.mergeBlockList("", SectionMeta, base.Meta, ours.Meta, theirs.Meta)
+	result.Links = m.mergeBlockList("", SectionLinks, base.Links, ours.Links, theirs.Links)
+
+	return &result, m.conflicts
+}
+
+type merger struct {
+	conflicts []Conflict
+	// added is set while merging a block that was added on both
+	// sides, its conflicts are add/add conflicts.
+	added bool
+}
+
+func (m *merger) conflict(path, field, message string, base, ours, theirs interface{}) {
+	if m.added && message == conflictBothModified {
+		message = conflictBothAdded
+	}
+
+	m.conflicts = append(m.conflicts, Conflict{
+		Path:    path,
+		Field:   field,
+		Message: message,
+		Base:    base,
+		Ours:    ours,
+		Theirs:  theirs,
+	})
+}
+
+func (m *merger) mergeString(path, field, base, ours, theirs string) string {
+	switch {
+	case ours == theirs, theirs == base:
+		return ours
+	case ours == base:
+		return theirs
+	}
+
+	m.conflict(path, field, conflictBothModified, base, ours, theirs)
+
+	return ours
+}
+
+func timesEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+func (m *merger) mergeTime(field string, base, ours, theirs *time.Time) *time.Time {
+	switch {
+	case timesEqual(ours, theirs), timesEqual(theirs, base):
+		return copyTime(ours)
+	case timesEqual(ours, base):
+		return copyTime(theirs)
+	}
+
+	m.conflict("", field, conflictTimeModified, base, ours, theirs)
+
+	return copyTime(ours)
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	c := *t
+
+	return &c
+}
+
+func (m *merger) mergeProducts(base, ours, theirs []string) []string {
+	result := ours
+
+	switch {
+	case stringSlicesEqual(ours, theirs), stringSlicesEqual(theirs, base):
+	case stringSlicesEqual(ours, base):
+		result = theirs
+	default:
+		m.conflict("", "products", conflictProductsChange, base, ours, theirs)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return append([]string{}, result...)
+}
+
+// mergeValue does a three-way merge of an optional value.
+func mergeValue(base string, inBase bool, ours string, inOurs bool, theirs string, inTheirs bool) (string, bool, bool) {
+	switch {
+	case inOurs == inTheirs && ours == theirs, inTheirs == inBase && theirs == base:
+		return ours, inOurs, false
+	case inOurs == inBase && ours == base:
+		return theirs, inTheirs, false
+	}
+
+	return ours, inOurs, true
+}
+
+func (m *merger) mergeMap(path, prefix string, base, ours, theirs map[string]string) map[string]string {
+	keys := make(map[string]bool)
+
+	for _, src := range []map[string]string{base, ours, theirs} {
+		for k := range src {
+			keys[k] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+
+	sort.Strings(sorted)
+
+	var result map[string]string
+
+	for _, k := range sorted {
+		bv, inBase := base[k]
+		ov, inOurs := ours[k]
+		tv, inTheirs := theirs[k]
+
+		v, ok, conflict := mergeValue(bv, inBase, ov, inOurs, tv, inTheirs)
+		if conflict {
+			m.conflict(path, prefix+k, conflictBothModified,
+				optionalValue(bv, inBase), optionalValue(ov, inOurs), optionalValue(tv, inTheirs))
+		}
+
+		if !ok {
+			continue
+		}
+
+		if result == nil {
+			result = make(map[string]string)
+		}
+
+		result[k] = v
+	}
+
+	return result
+}
+
+func optionalValue(v string, ok bool) interface{} {
+	if !ok {
+		return nil
+	}
+
+	return v
+}
+
+func propertiesEqual(a, b doc.Property) bool {
+	if a.Name != b.Name || a.Value != b.Value || len(a.Parameters) != len(b.Parameters) {
+		return false
+	}
+
+	for k, v := range a.Parameters {
+		if bv, ok := b.Parameters[k]; !ok || bv != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchProperties pairs each property of a side with the base property
+// it was derived from, or -1 for added properties. Properties are
+// matched by name. Repeated names are paired with an identical base
+// property first and in the order they occur otherwise, so that
+// removing one of them doesn't shift the others.
+func matchProperties(base, side []doc.Property) []int {
+	match := make([]int, len(side))
+	used := make([]bool, len(base))
+
+	pair := func(i int, equal bool) {
+		for j := range base {
+			if used[j] || base[j].Name != side[i].Name {
+				continue
+			}
+
+			if equal && !propertiesEqual(base[j], side[i]) {
+				continue
+			}
+
+			match[i] = j
+			used[j] = true
+
+			return
+		}
+	}
+
+	for i := range side {
+		match[i] = -1
+		pair(i, true)
+	}
+
+	for i := range side {
+		if match[i] < 0 {
+			pair(i, false)
+		}
+	}
+
+	return match
+}
+
+func (m *merger) mergeProperties(base, ours, theirs []doc.Property) []doc.Property {
+	oursToBase := matchProperties(base, ours)
+	theirsToBase := matchProperties(base, theirs)
+
+	baseToTheirs := make(map[int]int)
+	pairedTheirs := make(map[int]bool)
+
+	for t, i := range theirsToBase {
+		if i >= 0 {
+			baseToTheirs[i] = t
+		}
+	}
+
+	type entry struct {
+		base, ours, theirs int
+	}
+
+	entries := make([]entry, 0, len(ours)+len(theirs))
+
+	for o, i := range oursToBase {
+		e := entry{base: i, ours: o, theirs: -1}
+
+		if i >= 0 {
+			if t, ok := baseToTheirs[i]; ok {
+				e.theirs = t
+			}
+		} else {
+			// Pair up properties with the same name added on both
+			// sides.
+			for t, ti := range theirsToBase {
+				if ti < 0 && !pairedTheirs[t] && theirs[t].Name == ours[o].Name {
+					e.theirs = t
+					break
+				}
+			}
+		}
+
+		if e.theirs >= 0 {
+			pairedTheirs[e.theirs] = true
+		}
+
+		entries = append(entries, e)
+	}
+
+	for t, i := range theirsToBase {
+		if !pairedTheirs[t] {
+			entries = append(entries, entry{base: i, ours: -1, theirs: t})
+		}
+	}
+
+	var result []doc.Property
+
+	for _, e := range entries {
+		path := "/properties/" + strconv.Itoa(len(result))
+
+		switch {
+		case e.ours >= 0 && e.theirs >= 0:
+			var b doc.Property
+			if e.base >= 0 {
+				b = base[e.base]
+			}
+
+			o, t := ours[e.ours], theirs[e.theirs]
+
+			value, _, conflict := mergeValue(b.Value, e.base >= 0, o.Value, true, t.Value, true)
+			if conflict {
+				message := conflictBothModified
+				if e.base < 0 {
+					message = conflictBothAdded
+				}
+
+				m.conflict(path, "value", message, b.Value, o.Value, t.Value)
+			}
+
+			result = append(result, doc.Property{
+				Name:       o.Name,
+				Value:      value,
+				Parameters: m.mergeMap(path, "parameters.", b.Parameters, o.Parameters, t.Parameters),
+			})
+		case e.ours >= 0:
+			o := ours[e.ours]
+
+			if e.base >= 0 {
+				if propertiesEqual(base[e.base], o) {
+					continue
+				}
+
+				m.conflict(path, "", conflictDeletedTheirs, base[e.base], o, nil)
+			}
+
+			result = append(result, copyProperty(o))
+		default:
+			t := theirs[e.theirs]
+
+			if e.base >= 0 {
+				if propertiesEqual(base[e.base], t) {
+					continue
+				}
+
+				m.conflict(path, "", conflictDeletedOurs, base[e.base], nil, t)
+			}
+
+			result = append(result, copyProperty(t))
+		}
+	}
+
+	return result
+}
+
+func copyProperty(p doc.Property) doc.Property {
+	return *p.DeepCopy()
+}
+
+func blocksEqual(a, b *doc.Block) bool {
+	d := differ{}
+	d.diffBlock("", "", a, b)
+
+	return len(d.changes) == 0
+}
+
+type mergeEntry struct {
+	base, ours, theirs int
+}
+
+func (m *merger) mergeBlockList(parent string, section Section, base, ours, theirs []doc.Block) []doc.Block {
+	baseToOurs := make(map[int]int)
+	oursToBase := make(map[int]int)
+
+	for _, match := range MatchBlocks(base, ours) {
+		baseToOurs[match.A] = match.B
+		oursToBase[match.B] = match.A
+	}
+
+	baseToTheirs := make(map[int]int)
+	theirsToBase := make(map[int]int)
+
+	for _, match := range MatchBlocks(base, theirs) {
+		baseToTheirs[match.A] = match.B
+		theirsToBase[match.B] = match.A
+	}
+
+	// Pair up blocks that were added on both sides.
+	var oursAdded, theirsAdded []int
+	var oursAddedBlocks, theirsAddedBlocks []doc.Block
+
+	for j := range ours {
+		if _, ok := oursToBase[j]; !ok {
+			oursAdded = append(oursAdded, j)
+			oursAddedBlocks = append(oursAddedBlocks, ours[j])
+		}
+	}
+
+	for j := range theirs {
+		if _, ok := theirsToBase[j]; !ok {
+			theirsAdded = append(theirsAdded, j)
+			theirsAddedBlocks = append(theirsAddedBlocks, theirs[j])
+		}
+	}
+
+	oursToTheirsAdded := make(map[int]int)
+	pairedTheirs := make(map[int]bool)
+
+	for _, match := range MatchBlocks(oursAddedBlocks, theirsAddedBlocks) {
+		oursToTheirsAdded[oursAdded[match.A]] = theirsAdded[match.B]
+		pairedTheirs[theirsAdded[match.B]] = true
+	}
+
+	var entries []mergeEntry
+
+	for j := range ours {
+		e := mergeEntry{base: -1, ours: j, theirs: -1}
+
+		if i, ok := oursToBase[j]; ok {
+			e.base = i
+
+			if t, ok := baseToTheirs[i]; ok {
+				e.theirs = t
+			} else if blocksEqual(&base[i], &ours[j]) {
+				// Deleted in theirs and untouched in ours.
+				continue
+			}
+		} else if t, ok := oursToTheirsAdded[j]; ok {
+			e.theirs = t
+		}
+
+		entries = append(entries, e)
+	}
+
+	// Add blocks that only remain in theirs, placed after the block
+	// that precedes them in theirs.
+	for t := range theirs {
+		e := mergeEntry{base: -1, ours: -1, theirs: t}
+
+		if i, ok := theirsToBase[t]; ok {
+			if _, ok := baseToOurs[i]; ok {
+				continue
+			}
+
+			if blocksEqual(&base[i], &theirs[t]) {
+				// Deleted in ours and untouched in theirs.
+				continue
+			}
+
+			e.base = i
+		} else if pairedTheirs[t] {
+			continue
+		}
+
+		pos := 0
+
+		for k := len(entries) - 1; k >= 0; k-- {
+			if entries[k].theirs >= 0 && entries[k].theirs < t {
+				pos = k + 1
+				break
+			}
+		}
+
+		entries = append(entries, mergeEntry{})
+		copy(entries[pos+1:], entries[pos:])
+		entries[pos] = e
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	result := make([]doc.Block, len(entries))
+
+	for k, e := range entries {
+		path := parent + "/" + string(section) + "/" + strconv.Itoa(k)
+
+		switch {
+		case e.ours >= 0 && e.theirs >= 0:
+			var b doc.Block
+			if e.base >= 0 {
+				b = base[e.base]
+			}
+
+			added := m.added
+			m.added = added || e.base < 0
+			result[k] = m.mergeBlock(path, &b, &ours[e.ours], &theirs[e.theirs])
+			m.added = added
+		case e.ours >= 0:
+			if e.base >= 0 {
+				m.conflict(path, "", conflictDeletedTheirs, base[e.base], ours[e.ours], nil)
+			}
+
+			result[k] = copyBlock(ours[e.ours])
+		default:
+			if e.base >= 0 {
+				m.conflict(path, "", conflictDeletedOurs, base[e.base], nil, theirs[e.theirs])
+			}
+
+			result[k] = copyBlock(theirs[e.theirs])
+		}
+	}
+
+	return result
+}
+
+func (m *merger) mergeBlock(path string, base, ours, theirs *doc.Block) doc.Block {
+	return doc.Block{
+		ID:          m.mergeString(path, "id", base.ID, ours.ID, theirs.ID),
+		UUID:        m.mergeString(path, "uuid", base.UUID, ours.UUID, theirs.UUID),
+		URI:         m.mergeString(path, "uri", base.URI, ours.URI, theirs.URI),
+		URL:         m.mergeString(path, "url", base.URL, ours.URL, theirs.URL),
+		Type:        m.mergeString(path, "type", base.Type, ours.Type, theirs.Type),
+		Title:       m.mergeString(path, "title", base.Title, ours.Title, theirs.Title),
+		Data:        m.mergeMap(path, "data.", base.Data, ours.Data, theirs.Data),
+		Rel:         m.mergeString(path, "rel", base.Rel, ours.Rel, theirs.Rel),
+		Name:        m.mergeString(path, "name", base.Name, ours.Name, theirs.Name),
+		Value:       m.mergeString(path, "value", base.Value, ours.Value, theirs.Value),
+		ContentType: m.mergeString(path, "contentType", base.ContentType, ours.ContentType, theirs.ContentType),
+		Links:       m.mergeBlockList(path, SectionLinks, base.Links, ours.Links, theirs.Links),
+		Content:     m.mergeBlockList(path, SectionContent, base.Content, ours.Content, theirs.Content),
+		Meta:        m.mergeBlockList(path, SectionMeta, base.Meta, ours.Meta, theirs.Meta),
+		Role:        m.mergeString(path, "role", base.Role, ours.Role, theirs.Role),
+	}
+}
+
+// copyBlock returns a copy of the block that doesn't share any maps or
+// slices with the original.
+func copyBlock(b doc.Block) doc.Block {
+	return *b.DeepCopy()
+}
+
+func copyBlocks(blocks []doc.Block) []doc.Block {
+	if blocks == nil {
+		return nil
+	}
+
+	c := make([]doc.Block, len(blocks))
+	for i := range blocks {
+		c[i] = *blocks[i].DeepCopy()
+	}
+
+	return c
+}
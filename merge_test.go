@@ -0,0 +1,246 @@
+package navigadoc_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+func TestMerge3(t *testing.T) {
+	base := loadDocument(t, "./testdata/text.json")
+	ours := loadDocument(t, "./testdata/text.json")
+	theirs := loadDocument(t, "./testdata/text.json")
+
+	// Editor changes
+	ours.Title = "Editor title"
+	ours.Content[0].Data["text"] = "Editor header"
+	ours.Content = append(ours.Content, doc.Block{ID: "ours-new", Type: "x-im/paragraph"})
+	ours.Properties = append(ours.Properties, doc.Property{Name: "editor", Value: "yes"})
+
+	// Enricher changes
+	theirs.Status = "enriched"
+	theirs.Links = append(theirs.Links[:2], append([]doc.Block{
+		{Type: "x-im/category", Rel: "subject", UUID: "5d9b7a7e-2d6c-4a4b-9c2f-0c1b4b6f2a11"},
+	}, theirs.Links[2:]...)...)
+	theirs.Content[6].Links[0].Data["credit"] = "Enricher"
+	theirs.Meta = theirs.Meta[1:]
+
+	merged, conflicts := navigadoc.Merge3(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+
+	if merged.Title != "Editor title" || merged.Status != "enriched" {
+		t.Errorf("expected header changes from both sides, got %q %q", merged.Title, merged.Status)
+	}
+
+	if merged.Content[0].Data["text"] != "Editor header" {
+		t.Error("expected editor header change to be kept")
+	}
+
+	if merged.Content[6].Links[0].Data["credit"] != "Enricher" {
+		t.Error("expected enricher data to be kept")
+	}
+
+	if merged.Content[len(merged.Content)-1].ID != "ours-new" {
+		t.Error("expected the new paragraph to be kept")
+	}
+
+	if merged.Links[2].Type != "x-im/category" || len(merged.Links) != len(base.Links)+1 {
+		t.Error("expected the new link to be placed where theirs added it")
+	}
+
+	if len(merged.Meta) != len(base.Meta)-1 {
+		t.Error("expected the meta block deleted in theirs to be removed")
+	}
+
+	if len(merged.Properties) != len(base.Properties)+1 {
+		t.Error("expected the new property to be added")
+	}
+}
+
+func TestMerge3Conflicts(t *testing.T) {
+	base := &doc.Document{
+		Title: "Base",
+		Properties: []doc.Property{
+			{Name: "one", Value: "1"},
+		},
+		Links: []doc.Block{
+			{Rel: "author", UUID: "9e1653f3-7575-4cb7-9b74-dc4dea63513e", Title: "Author"},
+		},
+		Meta: []doc.Block{
+			{ID: "teaser", Type: "x-im/teaser", Data: map[string]string{"text": "Teaser"}},
+		},
+	}
+	ours := &doc.Document{
+		Title: "Ours",
+		Properties: []doc.Property{
+			{Name: "one", Value: "ours"},
+		},
+		Links: []doc.Block{
+			{Rel: "author", UUID: "9e1653f3-7575-4cb7-9b74-dc4dea63513e", Title: "Ours author"},
+		},
+	}
+	theirs := &doc.Document{
+		Title: "Theirs",
+		Properties: []doc.Property{
+			{Name: "one", Value: "theirs"},
+		},
+		Meta: []doc.Block{
+			{ID: "teaser", Type: "x-im/teaser", Data: map[string]string{"text": "Enriched teaser"}},
+		},
+	}
+
+	merged, conflicts := navigadoc.Merge3(base, ours, theirs)
+
+	expected := []struct {
+		path  string
+		field string
+	}{
+		{path: "", field: "title"},
+		{path: "/properties/0", field: "value"},
+		{path: "/meta/0", field: ""},
+		{path: "/links/0", field: ""},
+	}
+
+	if len(conflicts) != len(expected) {
+		t.Fatalf("expected %d conflicts, got %d: %v", len(expected), len(conflicts), conflicts)
+	}
+
+	for i, e := range expected {
+		if conflicts[i].Path != e.path || conflicts[i].Field != e.field {
+			t.Errorf("expected conflict at %s %s, got %s %s", e.path, e.field, conflicts[i].Path, conflicts[i].Field)
+		}
+	}
+
+	if merged.Title != "Ours" {
+		t.Errorf("expected conflicting title to keep ours, got %q", merged.Title)
+	}
+
+	if len(merged.Meta) != 1 || merged.Meta[0].Data["text"] != "Enriched teaser" {
+		t.Error("expected the block modified in theirs to be kept")
+	}
+
+	if len(merged.Links) != 1 || merged.Links[0].Title != "Ours author" {
+		t.Error("expected the block modified in ours to be kept")
+	}
+}
+
+func TestMerge3RepeatedProperties(t *testing.T) {
+	base := &doc.Document{
+		Properties: []doc.Property{
+			{Name: "definition", Value: "first"},
+			{Name: "definition", Value: "second"},
+			{Name: "definition", Value: "third"},
+		},
+	}
+
+	ours := base.DeepCopy()
+	ours.Properties[0].Value = "first ours"
+
+	theirs := base.DeepCopy()
+	theirs.Properties[1].Value = "second theirs"
+
+	// Both sides changed the third occurrence the same way.
+	base.Properties[2].Value = "third base"
+
+	merged, conflicts := navigadoc.Merge3(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+
+	expected := []string{"first ours", "second theirs", "third"}
+
+	if len(merged.Properties) != len(expected) {
+		t.Fatalf("expected %d properties, got %v", len(expected), merged.Properties)
+	}
+
+	for i, value := range expected {
+		p := merged.Properties[i]
+		if p.Name != "definition" || p.Value != value {
+			t.Errorf("expected property %d to be definition=%q, got %s=%q", i, value, p.Name, p.Value)
+		}
+	}
+
+	concept := loadDocument(t, "./examples/concept-invalid-xml.json")
+
+	merged, conflicts = navigadoc.Merge3(concept, concept, concept)
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+
+	if len(merged.Properties) != len(concept.Properties) {
+		t.Fatalf("expected %d properties, got %d", len(concept.Properties), len(merged.Properties))
+	}
+
+	for i := range concept.Properties {
+		if !merged.Properties[i].Equal(&concept.Properties[i]) {
+			t.Errorf("expected property %d to be %v, got %v", i, concept.Properties[i], merged.Properties[i])
+		}
+	}
+}
+
+func TestMerge3DeletedRepeatedProperty(t *testing.T) {
+	base := &doc.Document{
+		Properties: []doc.Property{
+			{Name: "definition", Value: "a"},
+			{Name: "definition", Value: "b"},
+		},
+	}
+
+	// Ours deletes the first occurrence, theirs changes the second.
+	ours := &doc.Document{
+		Properties: []doc.Property{
+			{Name: "definition", Value: "b"},
+		},
+	}
+	theirs := &doc.Document{
+		Properties: []doc.Property{
+			{Name: "definition", Value: "a"},
+			{Name: "definition", Value: "b theirs"},
+		},
+	}
+
+	merged, conflicts := navigadoc.Merge3(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+
+	if len(merged.Properties) != 1 || merged.Properties[0].Value != "b theirs" {
+		t.Errorf("expected only definition=\"b theirs\", got %v", merged.Properties)
+	}
+}
+
+func TestMerge3AddedOnBothSides(t *testing.T) {
+	base := &doc.Document{}
+	ours := &doc.Document{
+		Meta: []doc.Block{
+			{ID: "teaser", Type: "x-im/teaser", Data: map[string]string{"text": "Ours"}},
+		},
+	}
+	theirs := &doc.Document{
+		Meta: []doc.Block{
+			{ID: "teaser", Type: "x-im/teaser", Data: map[string]string{"text": "Theirs"}},
+		},
+	}
+
+	merged, conflicts := navigadoc.Merge3(base, ours, theirs)
+	if len(conflicts) != 1 {
+		t.Fatalf("expected one conflict, got %v", conflicts)
+	}
+
+	if conflicts[0].Path != "/meta/0" || !strings.Contains(conflicts[0].Message, "added on both sides") {
+		t.Errorf("expected an add/add conflict at /meta/0, got %v", conflicts[0])
+	}
+
+	if len(merged.Meta) != 1 || merged.Meta[0].Data["text"] != "Ours" {
+		t.Errorf("expected the block from ours to be kept, got %v", merged.Meta)
+	}
+
+	merged, conflicts = navigadoc.Merge3(base, ours, ours)
+	if len(conflicts) != 0 || len(merged.Meta) != 1 {
+		t.Errorf("expected identical additions to merge cleanly, got %v", conflicts)
+	}
+}
@@ -0,0 +1,794 @@
+// Package newsitem converts between NavigaDoc and the Naviga/OpenContent
+// NewsItem XML format, that is newsItem, conceptItem and planningItem
+// documents with article text as idf.
+//
+// Elements without a NavigaDoc counterpart are kept as blocks of the
+// type XMLElementType so that they survive a round-trip.
+package newsitem
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/xmlelement"
+)
+
+const (
+	namespaceNewsML    = "http://iptc.org/std/nar/2006-10-01/"
+	namespaceInfomaker = "http://www.infomaker.se/newsml/1.0"
+	namespaceIDF       = "http://www.infomaker.se/idf/1.0"
+	namespaceXML       = "http://www.w3.org/XML/1998/namespace"
+
+	// XMLElementType is the block type used for NewsItem XML elements
+	// that have no NavigaDoc counterpart. The block name is the
+	// element name, prefixed with the section it was found in for
+	// header elements, the URI is the element namespace, the value is
+	// the inner XML and data holds the attributes.
+	XMLElementType = "x-im/xml-element"
+)
+
+// idfElementTypes are the idf element types used for text blocks.
+var idfElementTypes = map[string]string{
+	"x-im/header":       "headline",
+	"x-im/subheadline":  "subheadline1",
+	"x-im/paragraph":    "body",
+	"x-im/preamble":     "preamble",
+	"x-im/blockquote":   "blockquote",
+	"x-im/preleadin":    "preleadin",
+	"x-im/madmansrow":   "madmansrow",
+	"x-im/pagedateline": "dateline",
+	"x-im/drophead":     "drophead",
+}
+
+// idfBlockTypes are the block types used for idf elements.
+var idfBlockTypes = map[string]string{
+	"headline":     "x-im/header",
+	"subheadline1": "x-im/subheadline",
+	"subheadline2": "x-im/subheadline",
+	"subheadline3": "x-im/subheadline",
+	"subheadline4": "x-im/subheadline",
+	"subheadline5": "x-im/subheadline",
+	"subheadline6": "x-im/subheadline",
+	"body":         "x-im/paragraph",
+	"preamble":     "x-im/preamble",
+	"blockquote":   "x-im/blockquote",
+	"preleadin":    "x-im/preleadin",
+	"madmansrow":   "x-im/madmansrow",
+	"dateline":     "x-im/pagedateline",
+	"drophead":     "x-im/drophead",
+}
+
+// conceptItemTypes are the document types stored as conceptItem, with
+// the IPTC concept nature used for them.
+var conceptItemTypes = map[string]string{
+	"x-im/author":       "cpnat:person",
+	"x-im/person":       "cpnat:person",
+	"x-im/organisation": "cpnat:organisation",
+	"x-im/place":        "cpnat:geoArea",
+	"x-im/poi":          "cpnat:poi",
+	"x-im/event":        "cpnat:event",
+	"x-im/category":     "cpnat:abstract",
+	"x-im/channel":      "cpnat:abstract",
+	"x-im/concept":      "cpnat:abstract",
+	"x-im/section":      "cpnat:abstract",
+	"x-im/story":        "cpnat:abstract",
+	"x-im/topic":        "cpnat:abstract",
+}
+
+var itemClassTypes = map[string]string{
+	"ninat:text":          "x-im/article",
+	"ninat:picture":       "x-im/image",
+	"ninat:graphic":       "x-im/image",
+	"ninat:video":         "x-im/video",
+	"ninat:audio":         "x-im/audio",
+	"cinat:concept":       "x-im/concept",
+	"plinat:newscoverage": "x-im/newscoverage",
+}
+
+// attrName returns the attribute name used for a block field, NewsItem
+// XML uses lowercase attribute names.
+func attrName(f xmlelement.BlockField) string {
+	return strings.ToLower(f.Name)
+}
+
+// ToNewsItemXML converts a document to Naviga/OpenContent NewsItem
+// XML. Concepts are written as a conceptItem, news coverage and
+// assignments as a planningItem and everything else as a newsItem.
+func ToNewsItemXML(document *doc.Document) ([]byte, error) {
+	if document == nil || reflect.ValueOf(*document).IsZero() {
+		return nil, navigadoc.ErrEmptyDoc
+	}
+
+	var root *xmlelement.Element
+
+	switch {
+	case conceptItemTypes[document.Type] != "":
+		root = newsItemRoot("conceptItem", document)
+		root.Add(
+			itemMetaToXML(document, "cinat:concept"),
+			conceptToXML(document),
+		)
+	case document.Type == "x-im/newscoverage" || document.Type == "x-im/assignment":
+		root = newsItemRoot("planningItem", document)
+		root.Add(
+			itemMetaToXML(document, "plinat:newscoverage"),
+			contentMetaToXML(document),
+		)
+	default:
+		root = newsItemRoot("newsItem", document)
+		root.Add(
+			itemMetaToXML(document, itemClassFromType(document.Type)),
+			contentMetaToXML(document),
+		)
+	}
+
+	root.Add(contentSetToXML(document))
+
+	data, err := xml.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal NewsItem XML: %w", err)
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// FromNewsItemXML converts a Naviga/OpenContent newsItem, conceptItem
+// or planningItem to a document. Elements without a NavigaDoc
+// counterpart are kept as blocks of the type XMLElementType.
+func FromNewsItemXML(data []byte) (*doc.Document, error) {
+	var root xmlelement.Element
+
+	err := xml.Unmarshal(data, &root)
+	if err != nil {
+		return nil, navigadoc.InvalidArgumentError{
+			Msg: fmt.Sprintf("invalid NewsItem XML: %v", err),
+			Err: err,
+		}
+	}
+
+	var empty error
+
+	switch root.XMLName.Local {
+	case "newsItem":
+		empty = navigadoc.ErrEmptyNewsItem
+	case "conceptItem":
+		empty = navigadoc.ErrEmptyConcept
+	case "planningItem":
+		empty = navigadoc.ErrEmptyPlanningItem
+	default:
+		return nil, fmt.Errorf("%w: %s", navigadoc.ErrUnsupportedType, root.XMLName.Local)
+	}
+
+	if len(root.Children) == 0 {
+		return nil, empty
+	}
+
+	document := &doc.Document{
+		UUID: root.Attr("guid"),
+	}
+
+	for _, a := range root.Attrs {
+		if a.Name.Space == namespaceXML && a.Name.Local == "lang" {
+			document.Language = a.Value
+		}
+	}
+
+	var itemClass string
+
+	for _, c := range root.Children {
+		switch c.XMLName.Local {
+		case "catalogRef":
+		case "itemMeta":
+			itemClass, err = itemMetaFromXML(c, document)
+			if err != nil {
+				return nil, err
+			}
+		case "contentMeta":
+			contentMetaFromXML(c, document)
+		case "concept":
+			conceptFromXML(c, document)
+		case "contentSet":
+			contentSetFromXML(c, document)
+		default:
+			document.Meta = append(document.Meta,
+				xmlElementBlock(root.XMLName.Local+"/"+c.XMLName.Local, c))
+		}
+	}
+
+	if document.Type == "" {
+		document.Type = itemClassTypes[itemClass]
+	}
+
+	if document.Type == "" && root.XMLName.Local == "conceptItem" {
+		document.Type = "x-im/concept"
+	}
+
+	return document, nil
+}
+
+func newsItemRoot(name string, document *doc.Document) *xmlelement.Element {
+	return xmlelement.New(name,
+		xmlelement.New("catalogRef").
+			SetAttr("href", "http://www.iptc.org/std/catalog/catalog.IPTC-G2-Standards_27.xml"),
+		xmlelement.New("catalogRef").
+			SetAttr("href", "http://infomaker.se/spec/catalog/catalog.infomaker.g2.1_0.xml"),
+	).
+		SetAttr("xmlns", namespaceNewsML).
+		SetAttr("conformance", "power").
+		SetAttr("guid", document.UUID).
+		SetAttr("standard", "NewsML-G2").
+		SetAttr("standardversion", "2.20").
+		SetAttr("version", "1").
+		SetAttr("xml:lang", document.Language)
+}
+
+func itemClassFromType(documentType string) string {
+	switch documentType {
+	case "x-im/image":
+		return "ninat:picture"
+	case "x-im/video":
+		return "ninat:video"
+	case "x-im/audio":
+		return "ninat:audio"
+	}
+
+	return "ninat:text"
+}
+
+func formatXMLTime(name string, t *time.Time) *xmlelement.Element {
+	if t == nil {
+		return nil
+	}
+
+	return xmlelement.NewText(name, t.Format(time.RFC3339Nano))
+}
+
+func parseXMLTime(section string, value string) (*time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value))
+	if err != nil {
+		return nil, navigadoc.InvalidArgumentError{
+			Msg: fmt.Sprintf("invalid time in %s: %v", section, err),
+			Err: err,
+		}
+	}
+
+	return &t, nil
+}
+
+func extProperty(name, value string) *xmlelement.Element {
+	if value == "" {
+		return nil
+	}
+
+	return xmlelement.New("itemMetaExtProperty").
+		SetAttr("type", "imext:"+name).
+		SetAttr("value", value)
+}
+
+func itemMetaToXML(document *doc.Document, itemClass string) *xmlelement.Element {
+	e := xmlelement.New("itemMeta",
+		xmlelement.New("itemClass").SetAttr("qcode", itemClass),
+	)
+
+	if document.Provider != "" {
+		e.Add(xmlelement.New("provider").SetAttr("literal", document.Provider))
+	}
+
+	e.Add(
+		formatXMLTime("versionCreated", document.Modified),
+		formatXMLTime("firstCreated", document.Created),
+	)
+
+	if document.Status != "" {
+		status := document.Status
+		if !strings.Contains(status, ":") {
+			status = "imext:" + status
+		}
+
+		e.Add(xmlelement.New("pubStatus").SetAttr("qcode", status))
+	}
+
+	if document.Title != "" {
+		e.Add(xmlelement.NewText("title", document.Title))
+	}
+
+	e.Add(
+		extProperty("type", document.Type),
+		extProperty("uri", document.URI),
+		extProperty("url", document.URL),
+		extProperty("path", document.Path),
+		extProperty("source", document.Source),
+	)
+
+	for _, p := range document.Products {
+		e.Add(extProperty("product", p))
+	}
+
+	if document.Published != nil {
+		e.Add(extProperty("pubstart", document.Published.Format(time.RFC3339Nano)))
+	}
+
+	if document.Unpublished != nil {
+		e.Add(extProperty("pubstop", document.Unpublished.Format(time.RFC3339Nano)))
+	}
+
+	if len(document.Properties) > 0 {
+		properties := xmlelement.New("properties").SetAttr("xmlns", namespaceInfomaker)
+		for _, p := range document.Properties {
+			properties.Add(propertyToXML(p))
+		}
+
+		e.Add(properties)
+	}
+
+	e.Add(blockListToXML("links", "link", document.Links, namespaceInfomaker))
+
+	return e
+}
+
+// itemMetaFromXML reads itemMeta into the document and returns the
+// item class.
+func itemMetaFromXML(e *xmlelement.Element, document *doc.Document) (string, error) {
+	var itemClass string
+
+	var err error
+
+	for _, c := range e.Children {
+		switch c.XMLName.Local {
+		case "itemClass":
+			itemClass = c.Attr("qcode")
+		case "provider":
+			document.Provider = firstNonEmpty(c.Attr("literal"), c.Attr("qcode"), c.Attr("uri"))
+		case "versionCreated":
+			document.Modified, err = parseXMLTime("itemMeta/versionCreated", c.Text)
+		case "firstCreated":
+			document.Created, err = parseXMLTime("itemMeta/firstCreated", c.Text)
+		case "pubStatus":
+			document.Status = strings.TrimPrefix(c.Attr("qcode"), "imext:")
+		case "title":
+			document.Title = c.Text
+		case "itemMetaExtProperty":
+			err = extPropertyFromXML(c, document)
+		case "properties":
+			for _, p := range c.Children {
+				document.Properties = append(document.Properties, propertyFromXML(p))
+			}
+		case "links":
+			document.Links = blockListFromXML(c)
+		default:
+			document.Meta = append(document.Meta, xmlElementBlock("itemMeta/"+c.XMLName.Local, c))
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return itemClass, nil
+}
+
+func extPropertyFromXML(e *xmlelement.Element, document *doc.Document) error {
+	var err error
+
+	value := e.Attr("value")
+
+	switch e.Attr("type") {
+	case "imext:type":
+		document.Type = value
+	case "imext:uri":
+		document.URI = value
+	case "imext:url":
+		document.URL = value
+	case "imext:path":
+		document.Path = value
+	case "imext:source":
+		document.Source = value
+	case "imext:product":
+		document.Products = append(document.Products, value)
+	case "imext:pubstart":
+		document.Published, err = parseXMLTime("imext:pubstart", value)
+	case "imext:pubstop":
+		document.Unpublished, err = parseXMLTime("imext:pubstop", value)
+	default:
+		document.Meta = append(document.Meta, xmlElementBlock("itemMeta/"+e.XMLName.Local, e))
+	}
+
+	return err
+}
+
+func propertyToXML(p doc.Property) *xmlelement.Element {
+	e := xmlelement.New("property").
+		SetAttr("name", p.Name).
+		SetAttr("value", p.Value)
+
+	for _, k := range xmlelement.SortedKeys(p.Parameters) {
+		e.Add(xmlelement.New("parameter").
+			SetAttr("name", k).
+			SetAttr("value", p.Parameters[k]))
+	}
+
+	return e
+}
+
+func propertyFromXML(e *xmlelement.Element) doc.Property {
+	p := doc.Property{
+		Name:  e.Attr("name"),
+		Value: e.Attr("value"),
+	}
+
+	for _, c := range e.Children {
+		if c.XMLName.Local != "parameter" {
+			continue
+		}
+
+		if p.Parameters == nil {
+			p.Parameters = make(map[string]string)
+		}
+
+		p.Parameters[c.Attr("name")] = c.Attr("value")
+	}
+
+	return p
+}
+
+func contentMetaToXML(document *doc.Document) *xmlelement.Element {
+	return xmlelement.New("contentMeta",
+		blockListToXML("metadata", "object", document.Meta, namespaceInfomaker),
+	)
+}
+
+func contentMetaFromXML(e *xmlelement.Element, document *doc.Document) {
+	for _, c := range e.Children {
+		if c.XMLName.Local == "metadata" {
+			document.Meta = append(document.Meta, blockListFromXML(c)...)
+			continue
+		}
+
+		document.Meta = append(document.Meta, xmlElementBlock("contentMeta/"+c.XMLName.Local, c))
+	}
+}
+
+func conceptToXML(document *doc.Document) *xmlelement.Element {
+	e := xmlelement.New("concept",
+		xmlelement.New("conceptId").SetAttr("uri", document.URI),
+		xmlelement.New("type").SetAttr("qcode", conceptItemTypes[document.Type]),
+	)
+
+	if document.Title != "" {
+		e.Add(xmlelement.NewText("name", document.Title))
+	}
+
+	return e.Add(blockListToXML("metadata", "object", document.Meta, namespaceInfomaker))
+}
+
+// conceptFromXML reads the concept element. Definitions are kept as
+// properties with their attributes as parameters.
+func conceptFromXML(e *xmlelement.Element, document *doc.Document) {
+	for _, c := range e.Children {
+		switch c.XMLName.Local {
+		case "conceptId":
+			if document.URI == "" {
+				document.URI = c.Attr("uri")
+			}
+		case "type":
+		case "name":
+			if document.Title == "" {
+				document.Title = c.Text
+			}
+		case "definition":
+			p := doc.Property{Name: "definition", Value: c.Content()}
+
+			for _, a := range c.Attrs {
+				if p.Parameters == nil {
+					p.Parameters = make(map[string]string)
+				}
+
+				p.Parameters[a.Name.Local] = a.Value
+			}
+
+			document.Properties = append(document.Properties, p)
+		case "metadata":
+			document.Meta = append(document.Meta, blockListFromXML(c)...)
+		default:
+			document.Meta = append(document.Meta, xmlElementBlock("concept/"+c.XMLName.Local, c))
+		}
+	}
+}
+
+func contentSetToXML(document *doc.Document) *xmlelement.Element {
+	if len(document.Content) == 0 {
+		return nil
+	}
+
+	group := xmlelement.New("group").
+		SetAttr("id", "body").
+		SetAttr("type", "body")
+
+	for _, b := range document.Content {
+		group.Add(contentBlockToXML(b))
+	}
+
+	idf := xmlelement.New("idf", group).
+		SetAttr("xmlns", namespaceIDF).
+		SetAttr("xml:lang", document.Language)
+
+	return xmlelement.New("contentSet",
+		xmlelement.New("inlineXML", idf).
+			SetAttr("contenttype", "application/vnd.infomaker.idf+xml"),
+	)
+}
+
+func contentSetFromXML(e *xmlelement.Element, document *doc.Document) {
+	inline := e.Child("inlineXML")
+	if inline == nil {
+		return
+	}
+
+	idf := inline.Child("idf")
+	if idf == nil {
+		return
+	}
+
+	for _, c := range idf.Children {
+		if c.XMLName.Local != "group" {
+			document.Content = append(document.Content, contentBlockFromXML(c))
+			continue
+		}
+
+		for _, gc := range c.Children {
+			document.Content = append(document.Content, contentBlockFromXML(gc))
+		}
+	}
+}
+
+// idfElementType returns the idf element type for blocks that only
+// carry well-formed HTML text.
+func idfElementType(b doc.Block) (string, bool) {
+	if b.Type == "" || len(b.Data) != 2 || b.Data["format"] != "html" {
+		return "", false
+	}
+
+	text, ok := b.Data["text"]
+	if !ok || !xmlelement.IsWellFormed(text) {
+		return "", false
+	}
+
+	if !hasOnlyFields(b, "id", "type") {
+		return "", false
+	}
+
+	if t, ok := idfElementTypes[b.Type]; ok {
+		return t, true
+	}
+
+	// Types that look like idf element types can't be written as
+	// elements, they would be read back as another block type.
+	if _, ok := idfBlockTypes[b.Type]; ok {
+		return "", false
+	}
+
+	return b.Type, true
+}
+
+func contentBlockToXML(b doc.Block) *xmlelement.Element {
+	if elementType, ok := idfElementType(b); ok {
+		e := xmlelement.New("element").
+			SetAttr("id", b.ID).
+			SetAttr("type", elementType)
+		e.Inner = b.Data["text"]
+
+		return e
+	}
+
+	if isRawXMLBlock(b) {
+		return rawXMLElement(b)
+	}
+
+	return blockToXML("object", b)
+}
+
+func contentBlockFromXML(e *xmlelement.Element) doc.Block {
+	switch e.XMLName.Local {
+	case "element":
+		b := doc.Block{
+			ID:   e.Attr("id"),
+			Type: e.Attr("type"),
+			Data: map[string]string{
+				"format": "html",
+				"text":   e.Inner,
+			},
+		}
+
+		if t, ok := idfBlockTypes[b.Type]; ok {
+			b.Type = t
+		}
+
+		return b
+	case "object":
+		return blockFromXML(e)
+	}
+
+	return xmlElementBlock(e.XMLName.Local, e)
+}
+
+func blockToXML(name string, b doc.Block) *xmlelement.Element {
+	e := xmlelement.New(name)
+
+	for _, f := range xmlelement.BlockFields {
+		e.SetAttr(attrName(f), *f.Get(&b))
+	}
+
+	if b.Data != nil {
+		data := xmlelement.New("data")
+
+		for _, k := range xmlelement.SortedKeys(b.Data) {
+			if xmlelement.IsName(k) {
+				data.Add(xmlelement.NewText(k, b.Data[k]))
+			} else {
+				data.Add(xmlelement.NewText("entry", b.Data[k]).SetAttr("key", k))
+			}
+		}
+
+		e.Add(data)
+	}
+
+	e.Add(
+		blockListToXML("links", "link", b.Links, ""),
+		blockListToXML("meta", "object", b.Meta, ""),
+	)
+
+	if len(b.Content) > 0 {
+		content := xmlelement.New("content")
+		for _, c := range b.Content {
+			content.Add(contentBlockToXML(c))
+		}
+
+		e.Add(content)
+	}
+
+	return e
+}
+
+func blockFromXML(e *xmlelement.Element) doc.Block {
+	var b doc.Block
+
+	for _, f := range xmlelement.BlockFields {
+		*f.Get(&b) = e.Attr(attrName(f))
+	}
+
+	for _, c := range e.Children {
+		switch c.XMLName.Local {
+		case "data":
+			b.Data = make(map[string]string, len(c.Children))
+
+			for _, d := range c.Children {
+				key := d.XMLName.Local
+				if key == "entry" && d.Attr("key") != "" {
+					key = d.Attr("key")
+				}
+
+				b.Data[key] = d.Content()
+			}
+		case "links":
+			b.Links = blockListFromXML(c)
+		case "meta":
+			b.Meta = blockListFromXML(c)
+		case "content":
+			for _, cc := range c.Children {
+				b.Content = append(b.Content, contentBlockFromXML(cc))
+			}
+		default:
+			b.Meta = append(b.Meta, xmlElementBlock(c.XMLName.Local, c))
+		}
+	}
+
+	return b
+}
+
+func blockListToXML(name, itemName string, blocks []doc.Block, namespace string) *xmlelement.Element {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	e := xmlelement.New(name).SetAttr("xmlns", namespace)
+	for _, b := range blocks {
+		e.Add(blockToXML(itemName, b))
+	}
+
+	return e
+}
+
+func blockListFromXML(e *xmlelement.Element) []doc.Block {
+	var blocks []doc.Block
+
+	for _, c := range e.Children {
+		blocks = append(blocks, blockFromXML(c))
+	}
+
+	return blocks
+}
+
+// xmlElementBlock keeps an element without a NavigaDoc counterpart as
+// a block.
+func xmlElementBlock(name string, e *xmlelement.Element) doc.Block {
+	b := doc.Block{
+		Type:  XMLElementType,
+		Name:  name,
+		URI:   e.XMLName.Space,
+		Value: e.Inner,
+	}
+
+	for _, a := range e.Attrs {
+		if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" {
+			continue
+		}
+
+		if b.Data == nil {
+			b.Data = make(map[string]string)
+		}
+
+		b.Data[a.Name.Local] = a.Value
+	}
+
+	return b
+}
+
+// isRawXMLBlock checks if a block created by xmlElementBlock can be
+// written back as the original element.
+func isRawXMLBlock(b doc.Block) bool {
+	if b.Type != XMLElementType || !xmlelement.IsName(b.Name) || !xmlelement.IsWellFormed(b.Value) {
+		return false
+	}
+
+	for k := range b.Data {
+		if !xmlelement.IsName(k) {
+			return false
+		}
+	}
+
+	return hasOnlyFields(b, "type", "name", "uri", "value")
+}
+
+func rawXMLElement(b doc.Block) *xmlelement.Element {
+	e := xmlelement.New(b.Name).SetAttr("xmlns", b.URI)
+
+	for _, k := range xmlelement.SortedKeys(b.Data) {
+		e.Attrs = append(e.Attrs, xml.Attr{Name: xml.Name{Local: k}, Value: b.Data[k]})
+	}
+
+	e.Inner = b.Value
+
+	return e
+}
+
+// hasOnlyFields checks that a block has no other attribute fields set
+// than the listed ones and no child blocks.
+func hasOnlyFields(b doc.Block, fields ...string) bool {
+	allowed := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		allowed[f] = true
+	}
+
+	for _, f := range xmlelement.BlockFields {
+		if !allowed[f.Name] && *f.Get(&b) != "" {
+			return false
+		}
+	}
+
+	return len(b.Links) == 0 && len(b.Meta) == 0 && len(b.Content) == 0
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
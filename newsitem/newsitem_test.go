@@ -0,0 +1,178 @@
+package newsitem_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/testutil"
+	"github.com/navigacontentlab/navigadoc/newsitem"
+)
+
+func TestRoundTrip(t *testing.T) {
+	files := []string{
+		"../testdata/text.json",
+		"../testdata/ampersand-article.json",
+		"../testdata/ampersand-image.json",
+		"../testdata/assignment.json",
+		"../testdata/assignment-empty-date.json",
+		"../testdata/custom-asset.json",
+		"../testdata/empty-blocks-example.json",
+		"../testdata/image.json",
+		"../testdata/image2.json",
+		"../testdata/list.json",
+		"../testdata/objecttexttocontent.json",
+		"../testdata/package.json",
+		"../testdata/pdf.json",
+		"../testdata/planningItem.json",
+		"../testdata/story-concept.json",
+		"../testdata/uuids-uppercase.json",
+		"../examples/concept-invalid-xml.json",
+		"../examples/naviga-image-example.json",
+	}
+
+	for _, file := range files {
+		file := file
+		t.Run(file, func(t *testing.T) {
+			original := testutil.LoadDocument(t, file)
+
+			data, err := newsitem.ToNewsItemXML(original)
+			testutil.Must(t, err, "could not convert to NewsItem XML")
+
+			converted, err := newsitem.FromNewsItemXML(data)
+			testutil.Must(t, err, "could not convert from NewsItem XML")
+
+			changes := navigadoc.Diff(original, converted)
+			if len(changes) != 0 {
+				t.Errorf("document changed in round trip:\n%s\n%s", changes, data)
+			}
+
+			if len(original.Properties) > 0 && !reflect.DeepEqual(original.Properties, converted.Properties) {
+				t.Errorf("properties changed in round trip:\n%v\n%v", original.Properties, converted.Properties)
+			}
+		})
+	}
+}
+
+func TestReservedCharacters(t *testing.T) {
+	original := testutil.LoadDocument(t, "../examples/concept-invalid-xml.json")
+
+	data, err := newsitem.ToNewsItemXML(original)
+	testutil.Must(t, err, "could not convert to NewsItem XML")
+
+	if !strings.Contains(string(data), "<conceptItem ") {
+		t.Errorf("expected a conceptItem:\n%s", data)
+	}
+
+	converted, err := newsitem.FromNewsItemXML(data)
+	testutil.Must(t, err, "could not convert from NewsItem XML")
+
+	if converted.Title != original.Title {
+		t.Errorf("expected title %q, got %q", original.Title, converted.Title)
+	}
+
+	for i, l := range original.Links {
+		if converted.Links[i].Title != l.Title {
+			t.Errorf("expected link title %q, got %q", l.Title, converted.Links[i].Title)
+		}
+	}
+}
+
+func TestFromNewsItemXML(t *testing.T) {
+	data, err := ioutil.ReadFile("../testdata/newsitem-article.xml")
+	testutil.Must(t, err, "could not open testfile")
+
+	document, err := newsitem.FromNewsItemXML(data)
+	testutil.Must(t, err, "could not convert from NewsItem XML")
+
+	if document.UUID != "8706660e-06d2-4ebe-bc3a-6c17cbfb6179" || document.Type != "x-im/article" {
+		t.Errorf("unexpected uuid %q and type %q", document.UUID, document.Type)
+	}
+
+	if document.Title != "Rhythm & Blues" || document.Status != "done" || document.Language != "sv" {
+		t.Errorf("unexpected title %q, status %q and language %q",
+			document.Title, document.Status, document.Language)
+	}
+
+	if len(document.Links) != 1 || document.Links[0].Data["email"] != "jane.doe@example.org" {
+		t.Errorf("unexpected links %v", document.Links)
+	}
+
+	var meta []string
+	for _, b := range document.Meta {
+		meta = append(meta, b.Type+":"+b.Name)
+	}
+
+	expectedMeta := []string{
+		newsitem.XMLElementType + ":itemMeta/service",
+		newsitem.XMLElementType + ":itemMeta/itemMetaExtProperty",
+		newsitem.XMLElementType + ":contentMeta/contentCreated",
+		"x-im/newsvalue:",
+	}
+
+	if !reflect.DeepEqual(meta, expectedMeta) {
+		t.Errorf("expected meta %v, got %v", expectedMeta, meta)
+	}
+
+	expectedContent := []doc.Block{
+		{
+			ID:   "d0dbf67d385e",
+			Type: "x-im/header",
+			Data: map[string]string{"format": "html", "text": "Rhythm &amp; Blues"},
+		},
+		{
+			ID:   "fafbedf02da1",
+			Type: "x-im/paragraph",
+			Data: map[string]string{"format": "html", "text": "Some <strong>bold</strong> text."},
+		},
+	}
+
+	if !reflect.DeepEqual(document.Content[:2], expectedContent) {
+		t.Errorf("expected content %v, got %v", expectedContent, document.Content[:2])
+	}
+
+	image := document.Content[2]
+	if image.Data["text"] != "text &amp; <strong>text</strong>" || image.Links[0].Rel != "crop" {
+		t.Errorf("unexpected image %v", image)
+	}
+
+	table := document.Content[3]
+	if table.Type != newsitem.XMLElementType || table.Name != "table" || table.Data["id"] != "t1" {
+		t.Errorf("unexpected element block %v", table)
+	}
+
+	// Unknown content elements are written back as they were
+	out, err := newsitem.ToNewsItemXML(document)
+	testutil.Must(t, err, "could not convert to NewsItem XML")
+
+	if !strings.Contains(string(out), `<tr><td>1</td></tr></table>`) {
+		t.Errorf("expected the table to be written back:\n%s", out)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := map[string]error{
+		`<newsItem xmlns="http://iptc.org/std/nar/2006-10-01/"/>`:     navigadoc.ErrEmptyNewsItem,
+		`<conceptItem xmlns="http://iptc.org/std/nar/2006-10-01/"/>`:  navigadoc.ErrEmptyConcept,
+		`<planningItem xmlns="http://iptc.org/std/nar/2006-10-01/"/>`: navigadoc.ErrEmptyPlanningItem,
+		`<packageItem xmlns="http://iptc.org/std/nar/2006-10-01/"/>`:  navigadoc.ErrUnsupportedType,
+		`<newsItem`: navigadoc.InvalidArgumentError{},
+		`<newsItem><itemMeta><firstCreated>yesterday</firstCreated></itemMeta></newsItem>`: navigadoc.InvalidArgumentError{},
+	}
+
+	for data, expected := range tests {
+		_, err := newsitem.FromNewsItemXML([]byte(data))
+		if !errors.Is(err, expected) {
+			t.Errorf("%s: expected %v, got %v", data, expected, err)
+		}
+	}
+
+	_, err := newsitem.ToNewsItemXML(&doc.Document{})
+	if !errors.Is(err, navigadoc.ErrEmptyDoc) {
+		t.Errorf("expected ErrEmptyDoc, got %v", err)
+	}
+}
@@ -0,0 +1,340 @@
+// Package newsml converts between NavigaDoc and IPTC NewsML-G2
+// newsItem, conceptItem and planningItem documents.
+//
+// Document fields are mapped to their NewsML-G2 counterparts where one
+// exists. Everything else is written as extension elements and
+// attributes in the NavigaDoc namespace so that a document survives a
+// round-trip unchanged. Elements that can't be mapped when importing
+// NewsML-G2 from other sources are preserved as document properties.
+package newsml
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/xmlelement"
+)
+
+const (
+	// NamespaceG2 is the NewsML-G2 namespace.
+	NamespaceG2 = "http://iptc.org/std/nar/2006-10-01/"
+	// NamespaceXHTML is used for inline XHTML content.
+	NamespaceXHTML = "http://www.w3.org/1999/xhtml"
+	// NamespaceNavigaDoc is used for extension elements and
+	// attributes that carry NavigaDoc data without a NewsML-G2
+	// counterpart.
+	NamespaceNavigaDoc = "https://github.com/navigacontentlab/navigadoc/newsml"
+
+	namespaceXML = "http://www.w3.org/XML/1998/namespace"
+
+	ndPrefix = "nd:"
+
+	// unmappedPrefix is used for the names of properties that hold
+	// NewsML-G2 elements without a NavigaDoc counterpart.
+	unmappedPrefix = "newsml:"
+)
+
+// is checks if an element has the local name and is outside the
+// NavigaDoc namespace.
+func is(e *xmlelement.Element, local string) bool {
+	return e.XMLName.Local == local && e.XMLName.Space != NamespaceNavigaDoc
+}
+
+func isND(e *xmlelement.Element, local string) bool {
+	return e.XMLName.Local == local && e.XMLName.Space == NamespaceNavigaDoc
+}
+
+// child returns the first child with the given local name outside the
+// NavigaDoc namespace.
+func child(e *xmlelement.Element, local string) *xmlelement.Element {
+	for _, c := range e.Children {
+		if is(c, local) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func ndChild(e *xmlelement.Element, local string) *xmlelement.Element {
+	for _, c := range e.Children {
+		if isND(c, local) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func childText(e *xmlelement.Element, local string) string {
+	c := child(e, local)
+	if c == nil {
+		return ""
+	}
+
+	return c.Text
+}
+
+func ndChildText(e *xmlelement.Element, local string) string {
+	c := ndChild(e, local)
+	if c == nil {
+		return ""
+	}
+
+	return c.Text
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
+
+func timeElement(name string, t *time.Time) *xmlelement.Element {
+	if t == nil {
+		return nil
+	}
+
+	return xmlelement.NewText(name, formatTime(t))
+}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value))
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// setBlockExtension writes the block fields that aren't listed in
+// mapped as NavigaDoc attributes, and data and child blocks as
+// NavigaDoc elements.
+func setBlockExtension(e *xmlelement.Element, b doc.Block, mapped ...string) {
+	skip := make(map[string]bool, len(mapped))
+	for _, m := range mapped {
+		skip[m] = true
+	}
+
+	for _, f := range xmlelement.BlockFields {
+		if !skip[f.Name] {
+			e.SetAttr(ndPrefix+f.Name, *f.Get(&b))
+		}
+	}
+
+	addBlockChildren(e, b)
+}
+
+// readBlockExtension reads the NavigaDoc attributes and elements
+// written by setBlockExtension.
+func readBlockExtension(e *xmlelement.Element, b *doc.Block) {
+	for _, f := range xmlelement.BlockFields {
+		if v := e.AttrNS(NamespaceNavigaDoc, f.Name); v != "" {
+			*f.Get(b) = v
+		}
+	}
+
+	readBlockChildren(e, b)
+}
+
+// blockElement writes a block as a NavigaDoc element.
+func blockElement(b doc.Block) *xmlelement.Element {
+	e := xmlelement.New(ndPrefix + "block")
+
+	for _, f := range xmlelement.BlockFields {
+		e.SetAttr(f.Name, *f.Get(&b))
+	}
+
+	addBlockChildren(e, b)
+
+	return e
+}
+
+func blockFromElement(e *xmlelement.Element) doc.Block {
+	var b doc.Block
+
+	for _, f := range xmlelement.BlockFields {
+		*f.Get(&b) = e.Attr(f.Name)
+	}
+
+	readBlockChildren(e, &b)
+
+	return b
+}
+
+func addBlockChildren(e *xmlelement.Element, b doc.Block) {
+	for _, k := range xmlelement.SortedKeys(b.Data) {
+		e.Add(xmlelement.NewText(ndPrefix+"data", b.Data[k]).SetAttr("key", k))
+	}
+
+	e.Add(
+		blockListElement("content", b.Content),
+		blockListElement("meta", b.Meta),
+		blockListElement("links", b.Links),
+	)
+}
+
+func readBlockChildren(e *xmlelement.Element, b *doc.Block) {
+	for _, c := range e.Children {
+		switch {
+		case isND(c, "data"):
+			if b.Data == nil {
+				b.Data = make(map[string]string)
+			}
+
+			b.Data[c.Attr("key")] = c.Text
+		case isND(c, "content"):
+			b.Content = blocksFromListElement(c)
+		case isND(c, "meta"):
+			b.Meta = blocksFromListElement(c)
+		case isND(c, "links"):
+			b.Links = blocksFromListElement(c)
+		}
+	}
+}
+
+func blockListElement(name string, blocks []doc.Block) *xmlelement.Element {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	e := xmlelement.New(ndPrefix + name)
+	for _, b := range blocks {
+		e.Add(blockElement(b))
+	}
+
+	return e
+}
+
+func blocksFromListElement(e *xmlelement.Element) []doc.Block {
+	var blocks []doc.Block
+
+	for _, c := range e.Children {
+		if isND(c, "block") {
+			blocks = append(blocks, blockFromElement(c))
+		}
+	}
+
+	return blocks
+}
+
+func propertyElement(p doc.Property) *xmlelement.Element {
+	e := xmlelement.New(ndPrefix+"property").
+		SetAttr("name", p.Name).
+		SetAttr("value", p.Value)
+
+	for _, k := range xmlelement.SortedKeys(p.Parameters) {
+		e.Add(xmlelement.New(ndPrefix+"parameter").
+			SetAttr("name", k).
+			SetAttr("value", p.Parameters[k]))
+	}
+
+	return e
+}
+
+func propertyFromElement(e *xmlelement.Element) doc.Property {
+	p := doc.Property{
+		Name:  e.Attr("name"),
+		Value: e.Attr("value"),
+	}
+
+	for _, c := range e.Children {
+		if isND(c, "parameter") {
+			if p.Parameters == nil {
+				p.Parameters = make(map[string]string)
+			}
+
+			p.Parameters[c.Attr("name")] = c.Attr("value")
+		}
+	}
+
+	return p
+}
+
+// unmappedProperty preserves an element that has no NavigaDoc
+// counterpart. Attributes are kept as parameters.
+func unmappedProperty(section string, e *xmlelement.Element) doc.Property {
+	p := doc.Property{
+		Name:  unmappedPrefix + section + "/" + e.XMLName.Local,
+		Value: strings.TrimSpace(e.Text),
+	}
+
+	if len(e.Children) > 0 {
+		p.Value = strings.TrimSpace(e.Inner)
+	}
+
+	for _, a := range e.Attrs {
+		if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" {
+			continue
+		}
+
+		if p.Parameters == nil {
+			p.Parameters = make(map[string]string)
+		}
+
+		p.Parameters[a.Name.Local] = a.Value
+	}
+
+	return p
+}
+
+// unmappedElements recreates the elements preserved by
+// unmappedProperty for a section.
+func unmappedElements(section string, properties []doc.Property) []*xmlelement.Element {
+	var elements []*xmlelement.Element
+
+	prefix := unmappedPrefix + section + "/"
+
+	for _, p := range properties {
+		if !strings.HasPrefix(p.Name, prefix) {
+			continue
+		}
+
+		e := xmlelement.New(strings.TrimPrefix(p.Name, prefix))
+
+		for _, k := range xmlelement.SortedKeys(p.Parameters) {
+			e.SetAttr(k, p.Parameters[k])
+		}
+
+		if xmlelement.IsWellFormed(p.Value) {
+			e.Inner = p.Value
+		} else {
+			e.Text = p.Value
+		}
+
+		elements = append(elements, e)
+	}
+
+	return elements
+}
+
+func isUnmapped(p doc.Property) bool {
+	return strings.HasPrefix(p.Name, unmappedPrefix)
+}
+
+// sortByPosition orders elements on the NavigaDoc position attribute,
+// elements without a position keep their relative order after the
+// positioned ones.
+func sortByPosition(elements []*xmlelement.Element) {
+	pos := func(e *xmlelement.Element) int {
+		p, err := strconv.Atoi(e.AttrNS(NamespaceNavigaDoc, "pos"))
+		if err != nil {
+			return int(^uint(0) >> 1)
+		}
+
+		return p
+	}
+
+	sort.SliceStable(elements, func(i, j int) bool {
+		return pos(elements[i]) < pos(elements[j])
+	})
+}
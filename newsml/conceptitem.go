@@ -0,0 +1,168 @@
+package newsml
+
+import (
+	"strconv"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/xmlelement"
+)
+
+var conceptTypes = map[string]string{
+	"x-im/event":        "cpnat:event",
+	"x-im/author":       "cpnat:person",
+	"x-im/person":       "cpnat:person",
+	"x-im/organisation": "cpnat:organisation",
+	"x-im/place":        "cpnat:geoArea",
+	"x-im/poi":          "cpnat:poi",
+	"x-im/story":        "cpnat:abstract",
+	"x-im/category":     "cpnat:abstract",
+	"x-im/channel":      "cpnat:abstract",
+	"x-im/section":      "cpnat:abstract",
+	"x-im/topic":        "cpnat:abstract",
+	"x-im/concept":      "cpnat:abstract",
+}
+
+// typeMetaBlock returns the first meta block that has the same type as
+// the document, it holds the type specific data for concepts and
+// planning items.
+func typeMetaBlock(document *doc.Document) *doc.Block {
+	for i := range document.Meta {
+		if document.Meta[i].Type == document.Type {
+			return &document.Meta[i]
+		}
+	}
+
+	return nil
+}
+
+func conceptItemFromDoc(document *doc.Document) *xmlelement.Element {
+	concept := xmlelement.New("concept",
+		xmlelement.New("conceptId").
+			SetAttr("uri", document.URI).
+			SetAttr("created", formatTime(document.Created)),
+		xmlelement.New("type").SetAttr("qcode", conceptTypes[document.Type]),
+	)
+
+	if document.Title != "" {
+		concept.Add(xmlelement.NewText("name", document.Title))
+	}
+
+	for i, b := range document.Links {
+		e := conceptRefElement("related", b).SetAttr("rel", b.Rel)
+		setBlockExtension(e, b, "uri", "type", "title", "rel")
+		e.SetAttr(ndPrefix+"pos", strconv.Itoa(i))
+		concept.Add(e)
+	}
+
+	// Event dates are derived from the event meta block, the block
+	// itself is preserved as an extension.
+	if meta := typeMetaBlock(document); meta != nil && document.Type == "x-im/event" {
+		dates := xmlelement.New("dates")
+		if start := meta.Data["start"]; start != "" {
+			dates.Add(xmlelement.NewText("start", start))
+		}
+
+		if end := meta.Data["end"]; end != "" {
+			dates.Add(xmlelement.NewText("end", end))
+		}
+
+		concept.Add(xmlelement.New("eventDetails", dates))
+	}
+
+	concept.Add(blockListElement("meta", document.Meta))
+	concept.Add(unmappedElements("concept", document.Properties)...)
+
+	root := itemRoot("conceptItem", document)
+	root.Add(
+		itemMetaFromDoc(document, itemClassFromType(ConceptItem, document.Type), nil),
+		concept,
+		blockListElement("content", document.Content),
+	)
+	root.Add(unmappedElements("conceptItem", document.Properties)...)
+
+	return root
+}
+
+func conceptItemToDoc(root *xmlelement.Element) (*doc.Document, error) {
+	document := itemRootToDoc(root)
+
+	var concept *xmlelement.Element
+
+	var links []*xmlelement.Element
+
+	for _, c := range root.Children {
+		switch {
+		case is(c, "itemMeta"):
+			l, err := itemMetaToDoc(c, document)
+			if err != nil {
+				return nil, err
+			}
+
+			links = append(links, l...)
+		case is(c, "concept"):
+			concept = c
+		case isND(c, "content"):
+			document.Content = blocksFromListElement(c)
+		case is(c, "catalogRef"):
+		case c.XMLName.Space != NamespaceNavigaDoc:
+			document.Properties = append(document.Properties, unmappedProperty("conceptItem", c))
+		}
+	}
+
+	if concept == nil || len(concept.Children) == 0 {
+		return nil, navigadoc.ErrEmptyConcept
+	}
+
+	var eventDetails *xmlelement.Element
+
+	for _, c := range concept.Children {
+		switch {
+		case is(c, "conceptId"):
+			if document.URI == "" {
+				document.URI = c.Attr("uri")
+			}
+		case is(c, "type"):
+		case is(c, "name"):
+			if document.Title == "" {
+				document.Title = c.Text
+			}
+		case is(c, "related"):
+			links = append(links, c)
+		case is(c, "eventDetails"):
+			eventDetails = c
+		case isND(c, "meta"):
+			document.Meta = blocksFromListElement(c)
+		case c.XMLName.Space != NamespaceNavigaDoc:
+			document.Properties = append(document.Properties, unmappedProperty("concept", c))
+		}
+	}
+
+	if document.Type == "" {
+		document.Type = "x-im/concept"
+
+		if eventDetails != nil {
+			document.Type = "x-im/event"
+		}
+	}
+
+	if eventDetails != nil && document.Meta == nil {
+		meta := doc.Block{Type: document.Type, Data: make(map[string]string)}
+
+		if dates := child(eventDetails, "dates"); dates != nil {
+			if start := childText(dates, "start"); start != "" {
+				meta.Data["start"] = start
+			}
+
+			if end := childText(dates, "end"); end != "" {
+				meta.Data["end"] = end
+			}
+		}
+
+		document.Meta = []doc.Block{meta}
+	}
+
+	document.Links = linksToDoc(links)
+
+	return document, nil
+}
@@ -0,0 +1,196 @@
+package newsml
+
+import (
+	"strconv"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/xmlelement"
+)
+
+// subjectRels are link relations that are written as subjects.
+var subjectRels = map[string]bool{
+	"subject":     true,
+	"category":    true,
+	"channel":     true,
+	"mainchannel": true,
+	"section":     true,
+	"topic":       true,
+	"story":       true,
+}
+
+// linkElements maps document links to NewsML-G2 elements. Authors and
+// contributors become creator and contributor elements and subject-like
+// links become subjects in contentMeta, all other links are written as
+// itemMeta links.
+func linkElements(links []doc.Block) (itemMeta []*xmlelement.Element, contentMeta []*xmlelement.Element) {
+	for i, b := range links {
+		var e *xmlelement.Element
+
+		switch {
+		case b.Rel == "author" || b.Rel == "contributor":
+			name := "creator"
+			if b.Rel == "contributor" {
+				name = "contributor"
+			}
+
+			e = conceptRefElement(name, b)
+			setBlockExtension(e, b, "uri", "type", "title", "rel")
+			contentMeta = append(contentMeta, e)
+		case subjectRels[b.Rel]:
+			e = conceptRefElement("subject", b)
+
+			if b.Rel == "subject" {
+				setBlockExtension(e, b, "uri", "type", "title", "rel")
+			} else {
+				setBlockExtension(e, b, "uri", "type", "title")
+			}
+
+			contentMeta = append(contentMeta, e)
+		default:
+			e = xmlelement.New("link").
+				SetAttr("rel", b.Rel).
+				SetAttr("residref", b.UUID).
+				SetAttr("href", b.URL).
+				SetAttr("contenttype", b.ContentType).
+				SetAttr("title", b.Title)
+			setBlockExtension(e, b, "rel", "uuid", "url", "contentType", "title")
+			itemMeta = append(itemMeta, e)
+		}
+
+		e.SetAttr(ndPrefix+"pos", strconv.Itoa(i))
+	}
+
+	return itemMeta, contentMeta
+}
+
+// conceptRefElement creates an element that references a concept,
+// f.ex. a subject or a creator.
+func conceptRefElement(name string, b doc.Block) *xmlelement.Element {
+	e := xmlelement.New(name).
+		SetAttr("uri", b.URI).
+		SetAttr("type", b.Type)
+
+	if b.Title != "" {
+		e.Add(xmlelement.NewText("name", b.Title))
+	}
+
+	return e
+}
+
+func conceptRefToBlock(e *xmlelement.Element, rel string) doc.Block {
+	b := doc.Block{
+		URI:   e.Attr("uri"),
+		Type:  e.Attr("type"),
+		Title: childText(e, "name"),
+		Rel:   rel,
+	}
+
+	if b.URI == "" {
+		b.URI = e.Attr("qcode")
+	}
+
+	if b.Title == "" {
+		b.Title = e.Attr("literal")
+	}
+
+	readBlockExtension(e, &b)
+
+	return b
+}
+
+func linkToBlock(e *xmlelement.Element) doc.Block {
+	b := doc.Block{
+		Rel:         e.Attr("rel"),
+		UUID:        e.Attr("residref"),
+		URL:         e.Attr("href"),
+		ContentType: e.Attr("contenttype"),
+		Title:       e.Attr("title"),
+	}
+
+	readBlockExtension(e, &b)
+
+	return b
+}
+
+// linksToDoc converts link-like elements back to blocks in their
+// original order.
+func linksToDoc(elements []*xmlelement.Element) []doc.Block {
+	sortByPosition(elements)
+
+	var links []doc.Block
+
+	for _, e := range elements {
+		switch {
+		case is(e, "creator"):
+			links = append(links, conceptRefToBlock(e, "author"))
+		case is(e, "contributor"):
+			links = append(links, conceptRefToBlock(e, "contributor"))
+		case is(e, "subject"):
+			links = append(links, conceptRefToBlock(e, "subject"))
+		case is(e, "related"):
+			links = append(links, conceptRefToBlock(e, e.Attr("rel")))
+		case is(e, "link"):
+			links = append(links, linkToBlock(e))
+		}
+	}
+
+	return links
+}
+
+// contentMetaFromDoc builds the contentMeta element for news and
+// planning items.
+func contentMetaFromDoc(document *doc.Document, links []*xmlelement.Element) *xmlelement.Element {
+	e := xmlelement.New("contentMeta")
+
+	// Creators and contributors are placed before the language and
+	// subjects after it.
+	for _, l := range links {
+		if !is(l, "subject") {
+			e.Add(l)
+		}
+	}
+
+	if document.Language != "" {
+		e.Add(xmlelement.New("language").SetAttr("tag", document.Language))
+	}
+
+	for _, l := range links {
+		if is(l, "subject") {
+			e.Add(l)
+		}
+	}
+
+	if document.Title != "" {
+		e.Add(xmlelement.NewText("headline", document.Title))
+	}
+
+	e.Add(blockListElement("meta", document.Meta))
+	e.Add(unmappedElements("contentMeta", document.Properties)...)
+
+	return e
+}
+
+// contentMetaToDoc reads the contentMeta element into the document and
+// returns the elements that represent links.
+func contentMetaToDoc(e *xmlelement.Element, document *doc.Document) []*xmlelement.Element {
+	var links []*xmlelement.Element
+
+	for _, c := range e.Children {
+		switch {
+		case is(c, "creator"), is(c, "contributor"), is(c, "subject"):
+			links = append(links, c)
+		case is(c, "language"):
+			document.Language = c.Attr("tag")
+		case is(c, "headline"):
+			if document.Title == "" {
+				document.Title = c.Text
+			}
+		case isND(c, "meta"):
+			document.Meta = blocksFromListElement(c)
+		case c.XMLName.Space != NamespaceNavigaDoc:
+			document.Properties = append(document.Properties, unmappedProperty("contentMeta", c))
+		}
+	}
+
+	return links
+}
@@ -0,0 +1,201 @@
+package newsml
+
+import (
+	"strings"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/xmlelement"
+)
+
+var standardStatuses = map[string]bool{
+	"usable":   true,
+	"withheld": true,
+	"canceled": true,
+}
+
+func pubStatusQCode(status string) string {
+	if status == "" {
+		return ""
+	}
+
+	if standardStatuses[status] {
+		return "stat:" + status
+	}
+
+	return "ndstat:" + status
+}
+
+func statusFromQCode(qcode string) string {
+	if i := strings.Index(qcode, ":"); i >= 0 {
+		return qcode[i+1:]
+	}
+
+	return qcode
+}
+
+// itemMetaFromDoc builds the itemMeta element, links are added by the
+// caller.
+func itemMetaFromDoc(document *doc.Document, itemClass string, links []*xmlelement.Element) *xmlelement.Element {
+	e := xmlelement.New("itemMeta",
+		xmlelement.New("itemClass").SetAttr("qcode", itemClass),
+	)
+
+	if document.Provider != "" {
+		e.Add(xmlelement.New("provider").SetAttr("literal", document.Provider))
+	}
+
+	e.Add(
+		timeElement("versionCreated", document.Modified),
+		timeElement("firstCreated", document.Created),
+	)
+
+	if document.Status != "" {
+		e.Add(xmlelement.New("pubStatus").SetAttr("qcode", pubStatusQCode(document.Status)))
+	}
+
+	if document.Source != "" {
+		e.Add(xmlelement.NewText("generator", document.Source))
+	}
+
+	if document.Type != "" {
+		e.Add(xmlelement.NewText("profile", document.Type))
+	}
+
+	if document.Title != "" {
+		e.Add(xmlelement.NewText("title", document.Title))
+	}
+
+	e.Add(links...)
+
+	if document.URI != "" {
+		e.Add(xmlelement.NewText(ndPrefix+"uri", document.URI))
+	}
+
+	if document.URL != "" {
+		e.Add(xmlelement.NewText(ndPrefix+"url", document.URL))
+	}
+
+	if document.Path != "" {
+		e.Add(xmlelement.NewText(ndPrefix+"path", document.Path))
+	}
+
+	for _, p := range document.Products {
+		e.Add(xmlelement.NewText(ndPrefix+"product", p))
+	}
+
+	e.Add(
+		timeElement(ndPrefix+"published", document.Published),
+		timeElement(ndPrefix+"unpublished", document.Unpublished),
+	)
+
+	for _, p := range document.Properties {
+		if !isUnmapped(p) {
+			e.Add(propertyElement(p))
+		}
+	}
+
+	e.Add(unmappedElements("itemMeta", document.Properties)...)
+
+	return e
+}
+
+// itemMetaToDoc reads the itemMeta element into the document and
+// returns the elements that represent links.
+func itemMetaToDoc(e *xmlelement.Element, document *doc.Document) ([]*xmlelement.Element, error) {
+	var links []*xmlelement.Element
+
+	var err error
+
+	for _, c := range e.Children {
+		switch {
+		case is(c, "itemClass"):
+			if document.Type == "" {
+				document.Type = typeFromItemClass(c.Attr("qcode"))
+			}
+		case is(c, "provider"):
+			document.Provider = c.Attr("literal")
+			if document.Provider == "" {
+				document.Provider = c.Attr("qcode")
+			}
+		case is(c, "versionCreated"):
+			document.Modified, err = parseTime(c.Text)
+		case is(c, "firstCreated"):
+			document.Created, err = parseTime(c.Text)
+		case is(c, "pubStatus"):
+			document.Status = statusFromQCode(c.Attr("qcode"))
+		case is(c, "generator"):
+			document.Source = c.Text
+		case is(c, "profile"):
+			document.Type = c.Text
+		case is(c, "title"):
+			document.Title = c.Text
+		case is(c, "link"):
+			links = append(links, c)
+		case isND(c, "uri"):
+			document.URI = c.Text
+		case isND(c, "url"):
+			document.URL = c.Text
+		case isND(c, "path"):
+			document.Path = c.Text
+		case isND(c, "product"):
+			document.Products = append(document.Products, c.Text)
+		case isND(c, "published"):
+			document.Published, err = parseTime(c.Text)
+		case isND(c, "unpublished"):
+			document.Unpublished, err = parseTime(c.Text)
+		case isND(c, "property"):
+			document.Properties = append(document.Properties, propertyFromElement(c))
+		case c.XMLName.Space != NamespaceNavigaDoc:
+			document.Properties = append(document.Properties, unmappedProperty("itemMeta", c))
+		}
+
+		if err != nil {
+			return nil, navigadoc.InvalidArgumentError{
+				Msg: "invalid itemMeta/" + c.XMLName.Local + ": " + err.Error(),
+				Err: err,
+			}
+		}
+	}
+
+	return links, nil
+}
+
+var itemClassTypes = map[string]string{
+	"ninat:text":          "x-im/article",
+	"ninat:picture":       "x-im/image",
+	"ninat:graphic":       "x-im/image",
+	"ninat:video":         "x-im/video",
+	"ninat:audio":         "x-im/audio",
+	"ninat:composite":     "x-im/package",
+	"plinat:newscoverage": "x-im/newscoverage",
+	"cinat:concept":       "x-im/concept",
+}
+
+func typeFromItemClass(qcode string) string {
+	return itemClassTypes[qcode]
+}
+
+// itemClassFromType picks the NewsML-G2 item class for a document
+// type.
+func itemClassFromType(kind ItemKind, documentType string) string {
+	switch kind {
+	case ConceptItem:
+		return "cinat:concept"
+	case PlanningItem:
+		return "plinat:newscoverage"
+	}
+
+	switch documentType {
+	case "x-im/image":
+		return "ninat:picture"
+	case "x-im/video":
+		return "ninat:video"
+	case "x-im/audio":
+		return "ninat:audio"
+	case "x-im/package", "x-im/list":
+		return "ninat:composite"
+	}
+
+	return "ninat:text"
+}
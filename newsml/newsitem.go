@@ -0,0 +1,339 @@
+package newsml
+
+import (
+	"strings"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/xmlelement"
+)
+
+// textBlockTags are the XHTML elements used for text blocks.
+var textBlockTags = map[string]string{
+	"x-im/header":      "h1",
+	"x-im/subheadline": "h2",
+	"x-im/paragraph":   "p",
+	"x-im/preamble":    "p",
+	"x-im/blockquote":  "blockquote",
+}
+
+// xhtmlBlockTypes are the block types used for XHTML elements from
+// other sources.
+var xhtmlBlockTypes = map[string]string{
+	"h1":         "x-im/header",
+	"h2":         "x-im/subheadline",
+	"h3":         "x-im/subheadline",
+	"h4":         "x-im/subheadline",
+	"h5":         "x-im/subheadline",
+	"h6":         "x-im/subheadline",
+	"p":          "x-im/paragraph",
+	"blockquote": "x-im/blockquote",
+}
+
+// isTextBlock checks if a block only carries text and can be written
+// as a plain XHTML element.
+func isTextBlock(b doc.Block) bool {
+	if _, ok := b.Data["text"]; !ok || b.Type == "" {
+		return false
+	}
+
+	for k := range b.Data {
+		if k != "text" && k != "format" {
+			return false
+		}
+	}
+
+	return b.UUID == "" && b.URI == "" && b.URL == "" && b.Title == "" &&
+		b.Rel == "" && b.Name == "" && b.Value == "" && b.ContentType == "" &&
+		b.Role == "" && len(b.Links) == 0 && len(b.Content) == 0 && len(b.Meta) == 0
+}
+
+func contentBlockElement(b doc.Block) *xmlelement.Element {
+	switch {
+	case b.Type == "x-im/image":
+		return imageElement(b)
+	case b.Type == "":
+		return blockElement(b)
+	case !isTextBlock(b):
+		return containerElement(b)
+	}
+
+	tag, ok := textBlockTags[b.Type]
+	if !ok {
+		tag = "p"
+	}
+
+	format := b.Data["format"]
+	text := b.Data["text"]
+
+	e := xmlelement.New(tag).
+		SetAttr("id", b.ID).
+		SetAttr("data-type", b.Type).
+		SetAttr("data-format", format)
+
+	switch {
+	case format != "html":
+		e.Text = text
+	case xmlelement.IsWellFormed(text):
+		e.Inner = text
+	default:
+		e.Text = text
+		e.SetAttr("data-escaped", "true")
+	}
+
+	return e
+}
+
+func contentBlockFromElement(e *xmlelement.Element) doc.Block {
+	tag := e.XMLName.Local
+
+	switch {
+	case isND(e, "block"):
+		return blockFromElement(e)
+	case tag == "figure":
+		return imageFromElement(e)
+	case tag == "div" && e.Attr("data-type") != "":
+		return containerFromElement(e)
+	}
+
+	b := doc.Block{
+		ID:   e.Attr("id"),
+		Type: e.Attr("data-type"),
+		Data: make(map[string]string),
+	}
+
+	format := e.Attr("data-format")
+
+	if b.Type == "" {
+		// XHTML from other sources
+		b.Type = xhtmlBlockTypes[tag]
+		if b.Type == "" {
+			b.Type = "xhtml/" + tag
+		}
+
+		format = "html"
+	}
+
+	if format != "" {
+		b.Data["format"] = format
+	}
+
+	if format == "html" && e.Attr("data-escaped") == "" {
+		b.Data["text"] = e.Inner
+	} else {
+		b.Data["text"] = e.Text
+	}
+
+	return b
+}
+
+// containerElement writes a content block that isn't plain text as a
+// div. The block fields are written as NavigaDoc attributes and
+// elements, and the child content blocks as XHTML.
+func containerElement(b doc.Block) *xmlelement.Element {
+	content := b.Content
+	b.Content = nil
+
+	e := xmlelement.New("div").
+		SetAttr("id", b.ID).
+		SetAttr("data-type", b.Type)
+
+	setBlockExtension(e, b, "id", "type")
+
+	for _, c := range content {
+		e.Add(contentBlockElement(c))
+	}
+
+	return e
+}
+
+func containerFromElement(e *xmlelement.Element) doc.Block {
+	b := doc.Block{
+		ID:   e.Attr("id"),
+		Type: e.Attr("data-type"),
+	}
+
+	readBlockExtension(e, &b)
+
+	for _, c := range e.Children {
+		if c.XMLName.Space != NamespaceNavigaDoc {
+			b.Content = append(b.Content, contentBlockFromElement(c))
+		}
+	}
+
+	return b
+}
+
+// imageElement writes an image as a figure. The image data is read
+// from the self link when there is one, the block itself is written
+// as NavigaDoc attributes and elements.
+func imageElement(b doc.Block) *xmlelement.Element {
+	image := &b
+
+	for i := range b.Links {
+		if b.Links[i].Rel == "self" {
+			image = &b.Links[i]
+			break
+		}
+	}
+
+	src := image.URL
+	if src == "" {
+		src = image.URI
+	}
+
+	e := xmlelement.New("figure").
+		SetAttr("id", b.ID).
+		SetAttr("data-type", b.Type)
+
+	setBlockExtension(e, b, "id", "type")
+
+	e.Add(xmlelement.New("img").
+		SetAttr("src", src).
+		SetAttr("alt", image.Data["alttext"]).
+		SetAttr("width", image.Data["width"]).
+		SetAttr("height", image.Data["height"]))
+
+	if text := image.Data["text"]; text != "" {
+		caption := xmlelement.New("figcaption")
+
+		if xmlelement.IsWellFormed(text) {
+			caption.Inner = text
+		} else {
+			caption.Text = text
+		}
+
+		e.Add(caption)
+	}
+
+	return e
+}
+
+// imageFromElement reads a figure written by imageElement, or creates
+// an image block from the img and figcaption of a figure from other
+// sources.
+func imageFromElement(e *xmlelement.Element) doc.Block {
+	b := doc.Block{
+		ID:   e.Attr("id"),
+		Type: e.Attr("data-type"),
+	}
+
+	if b.Type != "" {
+		readBlockExtension(e, &b)
+
+		return b
+	}
+
+	b.Type = "x-im/image"
+	b.Data = make(map[string]string)
+
+	if img := child(e, "img"); img != nil {
+		b.URL = img.Attr("src")
+
+		for _, k := range []string{"width", "height"} {
+			if v := img.Attr(k); v != "" {
+				b.Data[k] = v
+			}
+		}
+
+		if alt := img.Attr("alt"); alt != "" {
+			b.Data["alttext"] = alt
+		}
+	}
+
+	if caption := child(e, "figcaption"); caption != nil {
+		b.Data["text"] = strings.TrimSpace(caption.Content())
+	}
+
+	return b
+}
+
+func contentSetFromDoc(document *doc.Document) *xmlelement.Element {
+	if len(document.Content) == 0 {
+		return nil
+	}
+
+	body := xmlelement.New("body")
+	for _, b := range document.Content {
+		body.Add(contentBlockElement(b))
+	}
+
+	head := xmlelement.New("head")
+	if document.Title != "" {
+		head.Add(xmlelement.NewText("title", document.Title))
+	}
+
+	html := xmlelement.New("html", head, body).SetAttr("xmlns", NamespaceXHTML)
+
+	return xmlelement.New("contentSet",
+		xmlelement.New("inlineXML", html).SetAttr("contenttype", "application/xhtml+xml"),
+	)
+}
+
+func contentSetToDoc(e *xmlelement.Element, document *doc.Document) {
+	inline := child(e, "inlineXML")
+	if inline == nil {
+		return
+	}
+
+	html := child(inline, "html")
+	if html == nil {
+		return
+	}
+
+	body := child(html, "body")
+	if body == nil {
+		return
+	}
+
+	for _, c := range body.Children {
+		document.Content = append(document.Content, contentBlockFromElement(c))
+	}
+}
+
+func newsItemFromDoc(document *doc.Document) *xmlelement.Element {
+	itemLinks, contentLinks := linkElements(document.Links)
+
+	root := itemRoot("newsItem", document)
+	root.Add(
+		itemMetaFromDoc(document, itemClassFromType(NewsItem, document.Type), itemLinks),
+		contentMetaFromDoc(document, contentLinks),
+		contentSetFromDoc(document),
+	)
+	root.Add(unmappedElements("newsItem", document.Properties)...)
+
+	return root
+}
+
+func newsItemToDoc(root *xmlelement.Element) (*doc.Document, error) {
+	if len(root.Children) == 0 {
+		return nil, navigadoc.ErrEmptyNewsItem
+	}
+
+	document := itemRootToDoc(root)
+
+	var links []*xmlelement.Element
+
+	for _, c := range root.Children {
+		switch {
+		case is(c, "itemMeta"):
+			l, err := itemMetaToDoc(c, document)
+			if err != nil {
+				return nil, err
+			}
+
+			links = append(links, l...)
+		case is(c, "contentMeta"):
+			links = append(links, contentMetaToDoc(c, document)...)
+		case is(c, "contentSet"):
+			contentSetToDoc(c, document)
+		case is(c, "catalogRef"):
+		case c.XMLName.Space != NamespaceNavigaDoc:
+			document.Properties = append(document.Properties, unmappedProperty("newsItem", c))
+		}
+	}
+
+	document.Links = linksToDoc(links)
+
+	return document, nil
+}
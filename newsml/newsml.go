@@ -0,0 +1,152 @@
+package newsml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/xmlelement"
+)
+
+// ItemKind is the NewsML-G2 item used for a document.
+type ItemKind string
+
+const (
+	NewsItem     ItemKind = "newsItem"
+	ConceptItem  ItemKind = "conceptItem"
+	PlanningItem ItemKind = "planningItem"
+)
+
+var planningTypes = map[string]bool{
+	"x-im/newscoverage": true,
+	"x-im/assignment":   true,
+	"x-im/planning":     true,
+}
+
+// ItemKindForType returns the NewsML-G2 item kind used for a document
+// type. Concepts and events become concept items, news coverage and
+// assignments become planning items and everything else news items.
+func ItemKindForType(documentType string) ItemKind {
+	if _, ok := conceptTypes[documentType]; ok {
+		return ConceptItem
+	}
+
+	if planningTypes[documentType] {
+		return PlanningItem
+	}
+
+	return NewsItem
+}
+
+// FromDoc converts a document to the NewsML-G2 item for its type.
+func FromDoc(document *doc.Document) ([]byte, error) {
+	if document == nil || reflect.ValueOf(*document).IsZero() {
+		return nil, navigadoc.ErrEmptyDoc
+	}
+
+	var root *xmlelement.Element
+
+	switch ItemKindForType(document.Type) {
+	case ConceptItem:
+		root = conceptItemFromDoc(document)
+	case PlanningItem:
+		root = planningItemFromDoc(document)
+	default:
+		root = newsItemFromDoc(document)
+	}
+
+	data, err := xml.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal NewsML-G2: %w", err)
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// ToDoc converts a NewsML-G2 newsItem, conceptItem or planningItem to a
+// document.
+func ToDoc(data []byte) (*doc.Document, error) {
+	var root xmlelement.Element
+
+	err := xml.Unmarshal(data, &root)
+	if err != nil {
+		return nil, navigadoc.InvalidArgumentError{
+			Msg: fmt.Sprintf("invalid NewsML-G2 XML: %v", err),
+			Err: err,
+		}
+	}
+
+	switch {
+	case is(&root, string(NewsItem)):
+		return newsItemToDoc(&root)
+	case is(&root, string(ConceptItem)):
+		return conceptItemToDoc(&root)
+	case is(&root, string(PlanningItem)):
+		return planningItemToDoc(&root)
+	}
+
+	return nil, fmt.Errorf("%w: %s", navigadoc.ErrUnsupportedType, root.XMLName.Local)
+}
+
+// guidProperty holds the guid of an item when it isn't a UUID.
+const guidProperty = unmappedPrefix + "guid"
+
+func itemRoot(name string, document *doc.Document) *xmlelement.Element {
+	guid := document.UUID
+
+	for _, p := range document.Properties {
+		if guid == "" && p.Name == guidProperty {
+			guid = p.Value
+		}
+	}
+
+	// A document UUID that isn't a valid UUID is marked so that it
+	// isn't taken for a guid from other sources.
+	var invalidUUID string
+	if _, err := uuid.Parse(document.UUID); err != nil {
+		invalidUUID = document.UUID
+	}
+
+	return xmlelement.New(name,
+		xmlelement.New("catalogRef").
+			SetAttr("href", "http://www.iptc.org/std/catalog/catalog.IPTC-G2-Standards_38.xml"),
+	).
+		SetAttr("xmlns", NamespaceG2).
+		SetAttr("xmlns:nd", NamespaceNavigaDoc).
+		SetAttr("guid", guid).
+		SetAttr("version", "1").
+		SetAttr("standard", "NewsML-G2").
+		SetAttr("standardversion", "2.30").
+		SetAttr("conformance", "power").
+		SetAttr("xml:lang", document.Language).
+		SetAttr(ndPrefix+"uuid", invalidUUID)
+}
+
+func itemRootToDoc(root *xmlelement.Element) *doc.Document {
+	var document doc.Document
+
+	// Only UUIDs are used as document UUIDs, other guids, f.ex. URNs,
+	// are kept as a property.
+	guid := root.Attr("guid")
+	if _, err := uuid.Parse(guid); err == nil {
+		document.UUID = guid
+	} else if v := root.AttrNS(NamespaceNavigaDoc, "uuid"); v != "" {
+		document.UUID = v
+	} else if guid != "" {
+		document.Properties = append(document.Properties, doc.Property{
+			Name:  guidProperty,
+			Value: guid,
+		})
+	}
+
+	for _, a := range root.Attrs {
+		if a.Name.Space == namespaceXML && a.Name.Local == "lang" {
+			document.Language = a.Value
+		}
+	}
+
+	return &document
+}
@@ -0,0 +1,199 @@
+package newsml_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/testutil"
+	"github.com/navigacontentlab/navigadoc/newsml"
+)
+
+func TestRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../testdata/*.json")
+	testutil.Must(t, err, "could not list testdata")
+
+	for _, file := range files {
+		name := filepath.Base(file)
+
+		// event.json contains comments and empty-document-example.json
+		// has no document.
+		if name == "event.json" || name == "empty-document-example.json" {
+			continue
+		}
+
+		t.Run(name, func(t *testing.T) {
+			original := testutil.LoadDocument(t, file)
+
+			data, err := newsml.FromDoc(original)
+			testutil.Must(t, err, "could not convert to NewsML-G2")
+
+			converted, err := newsml.ToDoc(data)
+			testutil.Must(t, err, "could not convert from NewsML-G2")
+
+			changes := navigadoc.Diff(original, converted)
+			if len(changes) != 0 {
+				t.Errorf("document changed in round trip:\n%s\n%s", changes, data)
+			}
+		})
+	}
+}
+
+func TestItemKind(t *testing.T) {
+	tests := map[string]newsml.ItemKind{
+		"../testdata/text.json":          newsml.NewsItem,
+		"../testdata/image.json":         newsml.NewsItem,
+		"../testdata/story-concept.json": newsml.ConceptItem,
+		"../testdata/planningItem.json":  newsml.PlanningItem,
+		"../testdata/assignment.json":    newsml.PlanningItem,
+	}
+
+	for file, kind := range tests {
+		document := testutil.LoadDocument(t, file)
+
+		data, err := newsml.FromDoc(document)
+		testutil.Must(t, err, "could not convert to NewsML-G2")
+
+		if !strings.Contains(string(data), "<"+string(kind)+" ") {
+			t.Errorf("%s: expected a %s", file, kind)
+		}
+	}
+}
+
+func TestFromDocEmpty(t *testing.T) {
+	_, err := newsml.FromDoc(&doc.Document{})
+	if !errors.Is(err, navigadoc.ErrEmptyDoc) {
+		t.Errorf("expected ErrEmptyDoc, got %v", err)
+	}
+}
+
+func TestToDocEmptyItems(t *testing.T) {
+	tests := map[string]error{
+		`<newsItem xmlns="http://iptc.org/std/nar/2006-10-01/"/>`:     navigadoc.ErrEmptyNewsItem,
+		`<conceptItem xmlns="http://iptc.org/std/nar/2006-10-01/"/>`:  navigadoc.ErrEmptyConcept,
+		`<planningItem xmlns="http://iptc.org/std/nar/2006-10-01/"/>`: navigadoc.ErrEmptyPlanningItem,
+		`<packageItem xmlns="http://iptc.org/std/nar/2006-10-01/"/>`:  navigadoc.ErrUnsupportedType,
+	}
+
+	for data, expected := range tests {
+		_, err := newsml.ToDoc([]byte(data))
+		if !errors.Is(err, expected) {
+			t.Errorf("%s: expected %v, got %v", data, expected, err)
+		}
+	}
+
+	_, err := newsml.ToDoc([]byte("<newsItem"))
+
+	var invalid navigadoc.InvalidArgumentError
+	if !errors.As(err, &invalid) {
+		t.Errorf("expected InvalidArgumentError, got %v", err)
+	}
+}
+
+func TestToDocForeign(t *testing.T) {
+	data, err := ioutil.ReadFile("./testdata/newsitem.xml")
+	testutil.Must(t, err, "could not open testfile")
+
+	document, err := newsml.ToDoc(data)
+	testutil.Must(t, err, "could not convert from NewsML-G2")
+
+	// The guid isn't a UUID, it's kept as a property and written back.
+	if document.UUID != "" {
+		t.Errorf("unexpected uuid %q", document.UUID)
+	}
+
+	if document.Type != "x-im/article" || document.Status != "usable" {
+		t.Errorf("unexpected type %q and status %q", document.Type, document.Status)
+	}
+
+	if document.Title != "Storm hits the coast" || document.Language != "en" {
+		t.Errorf("unexpected title %q and language %q", document.Title, document.Language)
+	}
+
+	if document.Created == nil || document.Modified == nil {
+		t.Error("expected created and modified times")
+	}
+
+	var rels []string
+	for _, l := range document.Links {
+		rels = append(rels, l.Rel+":"+l.Title)
+	}
+
+	expectedRels := "author:Jane Doe,subject:Weather"
+	if strings.Join(rels, ",") != expectedRels {
+		t.Errorf("expected links %s, got %s", expectedRels, strings.Join(rels, ","))
+	}
+
+	var types []string
+	for _, b := range document.Content {
+		types = append(types, b.Type)
+	}
+
+	expectedTypes := "x-im/header,x-im/paragraph,xhtml/ul,x-im/image"
+	if strings.Join(types, ",") != expectedTypes {
+		t.Errorf("expected content %s, got %s", expectedTypes, strings.Join(types, ","))
+	}
+
+	if document.Content[1].Data["text"] != "Heavy <strong>winds</strong> today." {
+		t.Errorf("unexpected paragraph %q", document.Content[1].Data["text"])
+	}
+
+	image := document.Content[3]
+	if image.URL != "https://example.com/storm.jpg" || image.Data["text"] != "Waves at the pier" ||
+		image.Data["alttext"] != "Waves" || image.Data["width"] != "1024" || image.Data["height"] != "768" {
+		t.Errorf("unexpected image %v", image)
+	}
+
+	// Unknown elements are kept as properties and written back
+	properties := make(map[string]string)
+	for _, p := range document.Properties {
+		properties[p.Name] = p.Value
+	}
+
+	if properties["newsml:contentMeta/slugline"] != "storm" {
+		t.Fatalf("expected the slugline to be kept, got %v", document.Properties)
+	}
+
+	if properties["newsml:guid"] != "urn:newsml:example.com:20210310:article-1" {
+		t.Fatalf("expected the guid to be kept, got %v", document.Properties)
+	}
+
+	out, err := newsml.FromDoc(document)
+	testutil.Must(t, err, "could not convert to NewsML-G2")
+
+	if !strings.Contains(string(out), "<slugline") {
+		t.Errorf("expected the slugline to be written back:\n%s", out)
+	}
+
+	if !strings.Contains(string(out), `guid="urn:newsml:example.com:20210310:article-1"`) {
+		t.Errorf("expected the guid to be written back:\n%s", out)
+	}
+}
+
+func TestFromDocContent(t *testing.T) {
+	document := testutil.LoadDocument(t, "../testdata/text.json")
+
+	data, err := newsml.FromDoc(document)
+	testutil.Must(t, err, "could not convert to NewsML-G2")
+
+	// Text blocks inside other blocks are XHTML as well, images are
+	// figures.
+	expected := []string{
+		`<div id="MTU0LDE0MywyMTQsMTgw" data-type="x-im/content-part"`,
+		`<p id="paragraph-f6f5d97f5c8d6cd4977981ee4e609985" data-type="x-im/paragraph" data-format="html">` +
+			`<strong id="strong-88285daa39e05cb9bffd601c1e72322e">Quisque ac</strong></p>`,
+		`<figure id="dcc7c5fcf709" data-type="x-im/image"`,
+		`<img src="im://image/znX8U1C123JLDjlksdfgb40_jIka.jpeg" width="3560" height="2695"></img>` +
+			`<figcaption>Vivamus luctus eros.</figcaption></figure>`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(string(data), e) {
+			t.Errorf("expected the XHTML to contain\n%s\ngot\n%s", e, data)
+		}
+	}
+}
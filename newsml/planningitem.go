@@ -0,0 +1,103 @@
+package newsml
+
+import (
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/xmlelement"
+)
+
+func planningItemFromDoc(document *doc.Document) *xmlelement.Element {
+	itemLinks, contentLinks := linkElements(document.Links)
+
+	root := itemRoot("planningItem", document)
+	root.Add(
+		itemMetaFromDoc(document, itemClassFromType(PlanningItem, document.Type), itemLinks),
+		contentMetaFromDoc(document, contentLinks),
+	)
+
+	// The planning is derived from the planning meta block, the block
+	// itself is preserved as an extension in contentMeta.
+	if meta := typeMetaBlock(document); meta != nil {
+		planning := xmlelement.New("planning")
+
+		if t := meta.Data["type"]; t != "" {
+			planning.Add(xmlelement.New("g2contentType").SetAttr("qcode", t))
+		}
+
+		if start := meta.Data["start"]; start != "" {
+			planning.Add(xmlelement.NewText("scheduled", start))
+		}
+
+		if description := meta.Data["description"]; description != "" {
+			planning.Add(xmlelement.NewText("description", description))
+		}
+
+		root.Add(xmlelement.New("newsCoverageSet", xmlelement.New("newsCoverage", planning)))
+	}
+
+	root.Add(blockListElement("content", document.Content))
+	root.Add(unmappedElements("planningItem", document.Properties)...)
+
+	return root
+}
+
+func planningItemToDoc(root *xmlelement.Element) (*doc.Document, error) {
+	if len(root.Children) == 0 {
+		return nil, navigadoc.ErrEmptyPlanningItem
+	}
+
+	document := itemRootToDoc(root)
+
+	var links []*xmlelement.Element
+
+	var planning *xmlelement.Element
+
+	for _, c := range root.Children {
+		switch {
+		case is(c, "itemMeta"):
+			l, err := itemMetaToDoc(c, document)
+			if err != nil {
+				return nil, err
+			}
+
+			links = append(links, l...)
+		case is(c, "contentMeta"):
+			links = append(links, contentMetaToDoc(c, document)...)
+		case is(c, "newsCoverageSet"):
+			if coverage := child(c, "newsCoverage"); coverage != nil {
+				planning = child(coverage, "planning")
+			}
+		case isND(c, "content"):
+			document.Content = blocksFromListElement(c)
+		case is(c, "catalogRef"):
+		case c.XMLName.Space != NamespaceNavigaDoc:
+			document.Properties = append(document.Properties, unmappedProperty("planningItem", c))
+		}
+	}
+
+	if document.Type == "" {
+		document.Type = "x-im/newscoverage"
+	}
+
+	if planning != nil && document.Meta == nil {
+		meta := doc.Block{Type: document.Type, Data: make(map[string]string)}
+
+		if contentType := child(planning, "g2contentType"); contentType != nil {
+			meta.Data["type"] = contentType.Attr("qcode")
+		}
+
+		if start := childText(planning, "scheduled"); start != "" {
+			meta.Data["start"] = start
+		}
+
+		if description := childText(planning, "description"); description != "" {
+			meta.Data["description"] = description
+		}
+
+		document.Meta = []doc.Block{meta}
+	}
+
+	document.Links = linksToDoc(links)
+
+	return document, nil
+}
@@ -0,0 +1,663 @@
+package ninjs
+
+import (
+	"encoding/xml"
+	"html"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+const uuidURIPrefix = "urn:uuid:"
+
+var itemTypes = map[string]string{
+	"x-im/article": "text",
+	"x-im/image":   "picture",
+	"x-im/graphic": "graphic",
+	"x-im/video":   "video",
+	"x-im/audio":   "audio",
+	"x-im/package": "composite",
+	"x-im/list":    "composite",
+}
+
+var documentTypes = map[string]string{
+	"text":      "x-im/article",
+	"picture":   "x-im/image",
+	"graphic":   "x-im/graphic",
+	"video":     "x-im/video",
+	"audio":     "x-im/audio",
+	"composite": "x-im/package",
+}
+
+var pubStatuses = map[string]bool{
+	"usable":   true,
+	"withheld": true,
+	"canceled": true,
+}
+
+// subjectTypes are the link relations written as subjects, with the
+// block type used for them when reading ninjs.
+var subjectTypes = map[string]string{
+	"subject":     "",
+	"category":    "x-im/category",
+	"channel":     "x-im/channel",
+	"mainchannel": "x-im/channel",
+	"section":     "x-im/section",
+	"story":       "x-im/story",
+	"topic":       "x-im/topic",
+}
+
+// bodyTags are the HTML elements used for text blocks in the body.
+var bodyTags = map[string]string{
+	"x-im/header":      "h1",
+	"x-im/subheadline": "h2",
+	"x-im/paragraph":   "p",
+	"x-im/preamble":    "p",
+	"x-im/blockquote":  "blockquote",
+}
+
+// FromDocDocument fills the item from a document. The returned losses
+// point out the document data that ninjs can't represent.
+func (item *Item) FromDocDocument(document *doc.Document) ([]Loss, error) {
+	if document == nil {
+		return nil, navigadoc.ErrEmptyDoc
+	}
+
+	*item = Item{
+		URI:            entityURI(document.URI, document.UUID),
+		Type:           itemTypes[document.Type],
+		Profile:        document.Type,
+		VersionCreated: document.Modified,
+		ContentCreated: document.Created,
+		Language:       document.Language,
+	}
+
+	if item.URI == "" {
+		return nil, navigadoc.RequiredArgumentError{
+			Msg: "a document needs an uri or uuid to be converted to ninjs",
+		}
+	}
+
+	if document.URI != "" && document.UUID != "" {
+		item.AltIDs = []AltID{{Role: "uuid", Value: document.UUID}}
+	}
+
+	if item.Type == "" {
+		item.Type = "text"
+	}
+
+	if pubStatuses[document.Status] {
+		item.PubStatus = document.Status
+	}
+
+	if document.Title != "" {
+		item.Headlines = []Text{{Role: "main", Value: document.Title}}
+	}
+
+	var byline []string
+
+	for _, l := range document.Links {
+		entity := Entity{Name: l.Title, Rel: l.Rel, URI: entityURI(l.URI, l.UUID)}
+
+		if l.Rel == "author" {
+			item.People = append(item.People, entity)
+
+			if l.Title != "" {
+				byline = append(byline, l.Title)
+			}
+		} else if _, ok := subjectTypes[l.Rel]; ok {
+			item.Subjects = append(item.Subjects, entity)
+		}
+	}
+
+	item.By = strings.Join(byline, ", ")
+
+	var body strings.Builder
+
+	for _, b := range document.Content {
+		if b.Type == "x-im/image" {
+			if item.Associations == nil {
+				item.Associations = make(map[string]*Item)
+			}
+
+			key := "image" + strconv.Itoa(len(item.Associations)+1)
+			item.Associations[key] = imageAssociation(b)
+
+			continue
+		}
+
+		writeBodyBlock(&body, b)
+	}
+
+	if body.Len() > 0 {
+		item.BodyHTML = body.String()
+	}
+
+	// Whatever doesn't survive a conversion back to a document is lost.
+	var back doc.Document
+
+	_, err := item.ToDocDocument(&back)
+	if err != nil {
+		return nil, err
+	}
+
+	return changeLosses(navigadoc.Diff(&back, document)), nil
+}
+
+// ToDocDocument fills the document from the item. The returned losses
+// point out the ninjs data that has no document counterpart.
+func (item *Item) ToDocDocument(document *doc.Document) ([]Loss, error) {
+	if item.URI == "" {
+		return nil, navigadoc.RequiredArgumentError{Msg: "a ninjs item must have an uri"}
+	}
+
+	*document = doc.Document{
+		Type:     item.Profile,
+		Title:    item.headline(),
+		Modified: item.VersionCreated,
+		Created:  item.ContentCreated,
+		Status:   item.PubStatus,
+		Language: item.Language,
+	}
+
+	document.URI, document.UUID = item.identity()
+
+	if document.Type == "" {
+		document.Type = documentTypes[item.Type]
+	}
+
+	losses := item.scalarLosses(document)
+
+	for i, p := range item.People {
+		rel := p.Rel
+		if rel == "" {
+			rel = "author"
+		}
+
+		blockType := "x-im/person"
+		if rel == "author" {
+			blockType = "x-im/author"
+		}
+
+		document.Links = append(document.Links, entityBlock(p, rel, blockType))
+		losses = append(losses, literalLoss("/people/"+strconv.Itoa(i), p)...)
+	}
+
+	if len(item.People) == 0 && item.By != "" {
+		document.Links = append(document.Links, doc.Block{
+			Rel:   "author",
+			Type:  "x-im/author",
+			Title: item.By,
+		})
+	}
+
+	for i, s := range item.Subjects {
+		rel := s.Rel
+		if _, ok := subjectTypes[rel]; !ok {
+			rel = "subject"
+		}
+
+		document.Links = append(document.Links, entityBlock(s, rel, subjectTypes[rel]))
+		losses = append(losses, literalLoss("/subjects/"+strconv.Itoa(i), s)...)
+	}
+
+	entityLinks := []struct {
+		path     string
+		rel      string
+		entities []Entity
+	}{
+		{"/organisations/", "organisation", item.Organisations},
+		{"/places/", "place", item.Places},
+		{"/events/", "event", item.Events},
+	}
+
+	for _, el := range entityLinks {
+		for i, e := range el.entities {
+			document.Links = append(document.Links, entityBlock(e, el.rel, "x-im/"+el.rel))
+			losses = append(losses, literalLoss(el.path+strconv.Itoa(i), e)...)
+		}
+	}
+
+	losses = append(losses, item.bodyToDoc(document)...)
+	losses = append(losses, item.associationsToDoc(document)...)
+
+	return losses, nil
+}
+
+// identity returns the URI and UUID of the item, a "urn:uuid:" URI is
+// only used for the UUID.
+func (item *Item) identity() (string, string) {
+	var uri, uuid string
+
+	if strings.HasPrefix(item.URI, uuidURIPrefix) {
+		uuid = strings.TrimPrefix(item.URI, uuidURIPrefix)
+	} else {
+		uri = item.URI
+	}
+
+	for _, id := range item.AltIDs {
+		if id.Role == "uuid" {
+			uuid = id.Value
+		}
+	}
+
+	return uri, uuid
+}
+
+// headline returns the main headline, or the first one if there is no
+// main headline.
+func (item *Item) headline() string {
+	for _, h := range item.Headlines {
+		if h.Role == "main" {
+			return h.Value
+		}
+	}
+
+	if len(item.Headlines) > 0 {
+		return item.Headlines[0].Value
+	}
+
+	return item.Title
+}
+
+func (item *Item) scalarLosses(document *doc.Document) []Loss {
+	var losses []Loss
+
+	lost := func(path string, present bool) {
+		if present {
+			losses = append(losses, Loss{Path: path, Reason: "has no document counterpart"})
+		}
+	}
+
+	lost("/representationtype", item.RepresentationType != "")
+	lost("/version", item.Version != "")
+	lost("/firstcreated", item.FirstCreated != nil)
+	lost("/embargoed", item.Embargoed != nil)
+	lost("/urgency", item.Urgency != nil)
+	lost("/copyrightholder", item.CopyrightHolder != "")
+	lost("/copyrightnotice", item.CopyrightNotice != "")
+	lost("/usageterms", item.UsageTerms != "")
+	lost("/ednote", item.EdNote != "")
+	lost("/slugline", item.Slugline != "")
+	lost("/located", item.Located != "")
+	lost("/title", item.Title != "" && item.Title != document.Title)
+	lost("/descriptions", len(item.Descriptions) > 0)
+	lost("/objects", len(item.Objects) > 0)
+	lost("/genres", len(item.Genres) > 0)
+	lost("/keywords", len(item.Keywords) > 0)
+	lost("/renditions", len(item.Renditions) > 0)
+
+	for i, h := range item.Headlines {
+		if h.Value != document.Title {
+			lost("/headlines/"+strconv.Itoa(i), true)
+		}
+	}
+
+	for i, id := range item.AltIDs {
+		lost("/altids/"+strconv.Itoa(i), id.Role != "uuid")
+	}
+
+	return losses
+}
+
+// bodyToDoc converts the HTML body to content blocks.
+func (item *Item) bodyToDoc(document *doc.Document) []Loss {
+	var losses []Loss
+
+	body := item.BodyHTML
+
+	for i, b := range item.Bodies {
+		if b.ContentType == "text/html" && body == "" {
+			body = b.Value
+			continue
+		}
+
+		losses = append(losses, Loss{
+			Path:   "/bodies/" + strconv.Itoa(i),
+			Reason: "only one HTML body is supported",
+		})
+	}
+
+	document.Content = append(document.Content, bodyBlocks(body)...)
+
+	return losses
+}
+
+func (item *Item) associationsToDoc(document *doc.Document) []Loss {
+	var losses []Loss
+
+	keys := make([]string, 0, len(item.Associations))
+	for k := range item.Associations {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return naturalLess(keys[i], keys[j])
+	})
+
+	for _, k := range keys {
+		a := item.Associations[k]
+		if a == nil || a.Type != "picture" {
+			losses = append(losses, Loss{
+				Path:   "/associations/" + k,
+				Reason: "only picture associations are supported",
+			})
+
+			continue
+		}
+
+		document.Content = append(document.Content, imageBlock(a))
+	}
+
+	return losses
+}
+
+func entityURI(uri, uuid string) string {
+	if uri == "" && uuid != "" {
+		return uuidURIPrefix + uuid
+	}
+
+	return uri
+}
+
+func entityBlock(e Entity, rel, blockType string) doc.Block {
+	b := doc.Block{
+		Rel:   rel,
+		Type:  blockType,
+		Title: e.Name,
+	}
+
+	if strings.HasPrefix(e.URI, uuidURIPrefix) {
+		b.UUID = strings.TrimPrefix(e.URI, uuidURIPrefix)
+	} else {
+		b.URI = e.URI
+	}
+
+	return b
+}
+
+func literalLoss(path string, e Entity) []Loss {
+	if e.Literal == "" {
+		return nil
+	}
+
+	return []Loss{{Path: path + "/literal", Reason: "has no document counterpart"}}
+}
+
+// imageAssociation describes an image block, the image data is read
+// from the self link when there is one.
+func imageAssociation(b doc.Block) *Item {
+	image := b
+
+	for _, l := range b.Links {
+		if l.Rel == "self" {
+			image = l
+			break
+		}
+	}
+
+	a := &Item{
+		URI:     entityURI(image.URI, b.UUID),
+		Type:    "picture",
+		Profile: b.Type,
+	}
+
+	if image.URI != "" && b.UUID != "" {
+		a.AltIDs = []AltID{{Role: "uuid", Value: b.UUID}}
+	}
+
+	if text := image.Data["text"]; text != "" {
+		a.Descriptions = []Text{{Role: "caption", ContentType: "text/html", Value: text}}
+	}
+
+	width, _ := strconv.Atoi(image.Data["width"])
+	height, _ := strconv.Atoi(image.Data["height"])
+
+	if image.URL != "" || width > 0 || height > 0 {
+		a.Renditions = map[string]Rendition{
+			"original": {
+				Href:        image.URL,
+				ContentType: image.ContentType,
+				Width:       width,
+				Height:      height,
+			},
+		}
+	}
+
+	return a
+}
+
+func imageBlock(a *Item) doc.Block {
+	uri, uuid := a.identity()
+
+	blockType := a.Profile
+	if blockType == "" {
+		blockType = "x-im/image"
+	}
+
+	self := doc.Block{
+		Rel:  "self",
+		Type: blockType,
+		URI:  uri,
+		UUID: uuid,
+	}
+
+	setData := func(k, v string) {
+		if self.Data == nil {
+			self.Data = make(map[string]string)
+		}
+
+		self.Data[k] = v
+	}
+
+	for _, d := range a.Descriptions {
+		if d.Role == "caption" {
+			setData("text", d.Value)
+		}
+	}
+
+	if original, ok := a.Renditions["original"]; ok {
+		self.URL = original.Href
+		self.ContentType = original.ContentType
+
+		if original.Width > 0 {
+			setData("width", strconv.Itoa(original.Width))
+		}
+
+		if original.Height > 0 {
+			setData("height", strconv.Itoa(original.Height))
+		}
+	}
+
+	return doc.Block{
+		Type:  blockType,
+		UUID:  uuid,
+		Links: []doc.Block{self},
+	}
+}
+
+func writeBodyBlock(body *strings.Builder, b doc.Block) {
+	tag, ok := bodyTags[b.Type]
+	if !ok {
+		return
+	}
+
+	text := b.Data["text"]
+	if b.Data["format"] != "html" {
+		text = html.EscapeString(text)
+	}
+
+	body.WriteString("<" + tag)
+
+	if b.ID != "" {
+		body.WriteString(` id="` + html.EscapeString(b.ID) + `"`)
+	}
+
+	if b.Type == "x-im/preamble" {
+		body.WriteString(` class="preamble"`)
+	}
+
+	body.WriteString(">" + text + "</" + tag + ">")
+}
+
+// bodyBlocks splits an HTML body into blocks on its top level
+// elements.
+func bodyBlocks(body string) []doc.Block {
+	dec := xml.NewDecoder(strings.NewReader(body))
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	var (
+		blocks     []doc.Block
+		depth      int
+		start      xml.StartElement
+		outerStart int64
+		innerStart int64
+	)
+
+	for {
+		before := dec.InputOffset()
+
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				start = t.Copy()
+				outerStart = before
+				innerStart = dec.InputOffset()
+			}
+
+			depth++
+		case xml.EndElement:
+			depth--
+
+			if depth == 0 {
+				blocks = append(blocks, bodyBlock(start,
+					body[innerStart:before],
+					body[outerStart:dec.InputOffset()]))
+			}
+		case xml.CharData:
+			if depth == 0 && strings.TrimSpace(string(t)) != "" {
+				blocks = append(blocks, doc.Block{
+					Type: "x-im/paragraph",
+					Data: map[string]string{
+						"format": "html",
+						"text":   html.EscapeString(strings.TrimSpace(string(t))),
+					},
+				})
+			}
+		}
+	}
+
+	return blocks
+}
+
+func bodyBlock(start xml.StartElement, inner, outer string) doc.Block {
+	var id, class string
+
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "id":
+			id = a.Value
+		case "class":
+			class = a.Value
+		}
+	}
+
+	var blockType string
+
+	switch start.Name.Local {
+	case "p":
+		blockType = "x-im/paragraph"
+		if class == "preamble" {
+			blockType = "x-im/preamble"
+		}
+	case "h1":
+		blockType = "x-im/header"
+	case "h2", "h3", "h4", "h5", "h6":
+		blockType = "x-im/subheadline"
+	case "blockquote":
+		blockType = "x-im/blockquote"
+	default:
+		return doc.Block{
+			ID:   id,
+			Type: "x-im/htmlembed",
+			Data: map[string]string{"format": "html", "text": outer},
+		}
+	}
+
+	return doc.Block{
+		ID:   id,
+		Type: blockType,
+		Data: map[string]string{"format": "html", "text": inner},
+	}
+}
+
+// changeLosses describes the changes from a document converted back
+// from ninjs to the original document as losses, the paths point into
+// the original document.
+func changeLosses(changes navigadoc.Changes) []Loss {
+	losses := make([]Loss, 0, len(changes))
+
+	for _, c := range changes {
+		path := c.Path
+		if c.Field != "" {
+			path += "/" + strings.ReplaceAll(c.Field, ".", "/")
+		}
+
+		var reason string
+
+		switch {
+		case c.Kind == navigadoc.ChangeAdded:
+			reason = "can't be represented in ninjs"
+		case c.Kind == navigadoc.ChangeRemoved:
+			reason = "is added by the conversion"
+		case c.Kind == navigadoc.ChangeMoved:
+			reason = "doesn't keep its position"
+		case isEmpty(c.OldValue):
+			reason = "can't be represented in ninjs"
+		default:
+			reason = "isn't kept as is"
+		}
+
+		losses = append(losses, Loss{Path: path, Reason: reason})
+	}
+
+	return losses
+}
+
+func isEmpty(v interface{}) bool {
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
+
+// naturalLess orders association keys like "image2" before "image10".
+func naturalLess(a, b string) bool {
+	ta, na := splitNumberSuffix(a)
+	tb, nb := splitNumberSuffix(b)
+
+	if ta != tb {
+		return ta < tb
+	}
+
+	return na < nb
+}
+
+func splitNumberSuffix(s string) (string, int) {
+	i := len(s)
+	for i > 0 && s[i-1] >= '0' && s[i-1] <= '9' {
+		i--
+	}
+
+	n, _ := strconv.Atoi(s[i:])
+
+	return s[:i], n
+}
@@ -0,0 +1,279 @@
+package ninjs_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/testutil"
+	"github.com/navigacontentlab/navigadoc/ninjs"
+)
+
+func TestFromDocDocument(t *testing.T) {
+	tests := []struct {
+		file         string
+		uri          string
+		headline     string
+		by           string
+		subjects     []string
+		associations []string
+		body         string
+		losses       []string
+	}{
+		{
+			file:         "../testdata/text.json",
+			uri:          "im://article/1d02738f-7c99-42ba-a6da-3d1b97261523",
+			headline:     "Proin eget dignissim ipsum",
+			by:           "John Doe, Jane Doe",
+			subjects:     []string{"mainchannel:Premium", "channel:dd.se"},
+			associations: []string{"image1:im://image/znX8U1C123JLDjlksdfgb40_jIka.jpeg"},
+			body: `<h1 id="d0dbf67d385e">Lorem ipsum dolor sit</h1>` +
+				`<p id="fafbedf02da1">Mauris eleifend, `,
+			losses: []string{
+				"/published: can't be represented in ninjs",
+				"/unpublished: can't be represented in ninjs",
+				"/properties/0: can't be represented in ninjs",
+				"/content/7: can't be represented in ninjs",
+				"/content/6/links/0/data/alignment: can't be represented in ninjs",
+				"/meta/0: can't be represented in ninjs",
+				"/links/6/data/email: can't be represented in ninjs",
+			},
+		},
+		{
+			file:         "../testdata/ampersand-article.json",
+			uri:          "im://article/8706660e-06d2-4ebe-bc3a-6c17cbfb6179",
+			headline:     "some title",
+			associations: []string{"image1:im://image/0SC2BIoacGclDU0mYZ7o3C3xpxc.jpg"},
+			losses: []string{
+				"/content/0/id: can't be represented in ninjs",
+				"/content/0/links/0/links/0: can't be represented in ninjs",
+			},
+		},
+		{
+			file: "../testdata/objecttexttocontent.json",
+			uri:  "urn:uuid:1d02738f-7c99-42ba-a6da-3d1b97261523",
+			body: `<h1 id="d0dbf67d385e">Lorem ipsum dolor sit</h1>`,
+			losses: []string{
+				"/content/1: can't be represented in ninjs",
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.file, func(t *testing.T) {
+			document := testutil.LoadDocument(t, test.file)
+
+			var item ninjs.Item
+
+			losses, err := item.FromDocDocument(document)
+			testutil.Must(t, err, "could not convert to ninjs")
+
+			if item.URI != test.uri {
+				t.Errorf("expected uri %q, got %q", test.uri, item.URI)
+			}
+
+			var headline string
+			if len(item.Headlines) > 0 {
+				headline = item.Headlines[0].Value
+			}
+
+			if headline != test.headline {
+				t.Errorf("expected headline %q, got %q", test.headline, headline)
+			}
+
+			if item.By != test.by {
+				t.Errorf("expected byline %q, got %q", test.by, item.By)
+			}
+
+			var subjects []string
+			for _, s := range item.Subjects {
+				subjects = append(subjects, s.Rel+":"+s.Name)
+			}
+
+			if !reflect.DeepEqual(subjects, test.subjects) {
+				t.Errorf("expected subjects %v, got %v", test.subjects, subjects)
+			}
+
+			var associations []string
+			for k, a := range item.Associations {
+				associations = append(associations, k+":"+a.URI)
+			}
+
+			if !reflect.DeepEqual(associations, test.associations) {
+				t.Errorf("expected associations %v, got %v", test.associations, associations)
+			}
+
+			if !strings.HasPrefix(item.BodyHTML, test.body) {
+				t.Errorf("expected body to start with %q, got %q", test.body, item.BodyHTML)
+			}
+
+			reported := make(map[string]bool, len(losses))
+			for _, l := range losses {
+				reported[l.String()] = true
+			}
+
+			for _, l := range test.losses {
+				if !reported[l] {
+					t.Errorf("expected the loss %q to be reported, got %v", l, losses)
+				}
+			}
+		})
+	}
+}
+
+func TestToDocDocument(t *testing.T) {
+	data := []byte(`{
+  "uri": "urn:uuid:5f8c7b3e-6d43-4b4e-9c1a-2b2d0c1f8d11",
+  "type": "text",
+  "versioncreated": "2021-03-10T12:30:00Z",
+  "firstcreated": "2021-03-10T09:00:00+01:00",
+  "pubstatus": "usable",
+  "language": "en",
+  "urgency": 3,
+  "headlines": [{"role": "main", "value": "Storm hits the coast"}],
+  "people": [{"name": "Jane Doe", "rel": "author", "uri": "im://author/jane"}],
+  "subjects": [{"name": "Weather", "rel": "category", "uri": "urn:uuid:0b0a9b5e-5e8b-4b8c-8d7a-1c2b3d4e5f60"}],
+  "body_html": "<p class=\"preamble\">Heavy winds.</p><p id=\"p1\">Stay <em>inside</em>.</p><table><tr><td>1</td></tr></table>",
+  "associations": {
+    "image1": {
+      "uri": "im://image/storm.jpeg",
+      "type": "picture",
+      "altids": [{"role": "uuid", "value": "8e3f1c27-54f6-5d0e-9e55-3d0b6ad9c1c2"}],
+      "descriptions": [{"role": "caption", "value": "The storm"}],
+      "renditions": {"original": {"width": 1024, "height": 768}}
+    },
+    "video1": {"uri": "im://video/storm", "type": "video"}
+  }
+}`)
+
+	var item ninjs.Item
+
+	err := json.Unmarshal(data, &item)
+	testutil.Must(t, err, "could not unmarshal ninjs")
+
+	var document doc.Document
+
+	losses, err := item.ToDocDocument(&document)
+	testutil.Must(t, err, "could not convert from ninjs")
+
+	if document.UUID != "5f8c7b3e-6d43-4b4e-9c1a-2b2d0c1f8d11" || document.URI != "" {
+		t.Errorf("unexpected uuid %q and uri %q", document.UUID, document.URI)
+	}
+
+	if document.Type != "x-im/article" || document.Title != "Storm hits the coast" {
+		t.Errorf("unexpected type %q and title %q", document.Type, document.Title)
+	}
+
+	expectedLinks := []doc.Block{
+		{Rel: "author", Type: "x-im/author", Title: "Jane Doe", URI: "im://author/jane"},
+		{Rel: "category", Type: "x-im/category", Title: "Weather", UUID: "0b0a9b5e-5e8b-4b8c-8d7a-1c2b3d4e5f60"},
+	}
+
+	if !reflect.DeepEqual(document.Links, expectedLinks) {
+		t.Errorf("expected links %v, got %v", expectedLinks, document.Links)
+	}
+
+	expectedContent := []doc.Block{
+		{Type: "x-im/preamble", Data: map[string]string{"format": "html", "text": "Heavy winds."}},
+		{ID: "p1", Type: "x-im/paragraph", Data: map[string]string{"format": "html", "text": "Stay <em>inside</em>."}},
+		{Type: "x-im/htmlembed", Data: map[string]string{"format": "html", "text": "<table><tr><td>1</td></tr></table>"}},
+		{
+			Type: "x-im/image",
+			UUID: "8e3f1c27-54f6-5d0e-9e55-3d0b6ad9c1c2",
+			Links: []doc.Block{{
+				Rel:  "self",
+				Type: "x-im/image",
+				URI:  "im://image/storm.jpeg",
+				UUID: "8e3f1c27-54f6-5d0e-9e55-3d0b6ad9c1c2",
+				Data: map[string]string{"text": "The storm", "width": "1024", "height": "768"},
+			}},
+		},
+	}
+
+	if !reflect.DeepEqual(document.Content, expectedContent) {
+		t.Errorf("expected content\n%v\ngot\n%v", expectedContent, document.Content)
+	}
+
+	var reported []string
+	for _, l := range losses {
+		reported = append(reported, l.Path)
+	}
+
+	expectedLosses := []string{"/firstcreated", "/urgency", "/associations/video1"}
+	if !reflect.DeepEqual(reported, expectedLosses) {
+		t.Errorf("expected losses %v, got %v", expectedLosses, losses)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	document := &doc.Document{
+		UUID:     "5f8c7b3e-6d43-4b4e-9c1a-2b2d0c1f8d11",
+		Type:     "x-im/article",
+		Title:    "Rhythm & Blues",
+		Language: "en",
+		Status:   "usable",
+		Links: []doc.Block{
+			{Rel: "author", Type: "x-im/author", Title: "Jane Doe", URI: "im://author/jane"},
+			{Rel: "channel", Type: "x-im/channel", Title: "Music", UUID: "0b0a9b5e-5e8b-4b8c-8d7a-1c2b3d4e5f60"},
+		},
+		Content: []doc.Block{
+			{ID: "h1", Type: "x-im/header", Data: map[string]string{"format": "html", "text": "Rhythm &amp; Blues"}},
+			{ID: "p1", Type: "x-im/paragraph", Data: map[string]string{"format": "html", "text": "A <strong>soulful</strong> genre."}},
+		},
+	}
+
+	var item ninjs.Item
+
+	losses, err := item.FromDocDocument(document)
+	testutil.Must(t, err, "could not convert to ninjs")
+
+	if len(losses) != 0 {
+		t.Errorf("expected a lossless conversion, got %v", losses)
+	}
+
+	data, err := json.Marshal(item)
+	testutil.Must(t, err, "could not marshal ninjs")
+
+	if !strings.Contains(string(data), `"body_html":`) {
+		t.Errorf("expected the body to be written to body_html, got %s", data)
+	}
+
+	var parsed ninjs.Item
+
+	err = json.Unmarshal(data, &parsed)
+	testutil.Must(t, err, "could not unmarshal ninjs")
+
+	var converted doc.Document
+
+	_, err = parsed.ToDocDocument(&converted)
+	testutil.Must(t, err, "could not convert from ninjs")
+
+	changes := navigadoc.Diff(document, &converted)
+	if len(changes) != 0 {
+		t.Errorf("document changed in round trip:\n%s", changes)
+	}
+}
+
+func TestConversionErrors(t *testing.T) {
+	var item ninjs.Item
+
+	_, err := item.FromDocDocument(nil)
+	if !errors.Is(err, navigadoc.ErrEmptyDoc) {
+		t.Errorf("expected ErrEmptyDoc, got %v", err)
+	}
+
+	_, err = item.FromDocDocument(&doc.Document{Type: "x-im/article"})
+	if !errors.Is(err, navigadoc.RequiredArgumentError{}) {
+		t.Errorf("expected RequiredArgumentError, got %v", err)
+	}
+
+	_, err = (&ninjs.Item{}).ToDocDocument(&doc.Document{})
+	if !errors.Is(err, navigadoc.RequiredArgumentError{}) {
+		t.Errorf("expected RequiredArgumentError, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+// Package ninjs converts between NavigaDoc and IPTC ninjs 2.x.
+//
+// ninjs can't represent everything a document holds, so conversions
+// report the data that didn't survive instead of dropping it silently.
+package ninjs
+
+import "time"
+
+// Item is a ninjs 2.x item.
+type Item struct {
+	URI                string               `json:"uri"`
+	Type               string               `json:"type,omitempty"`
+	RepresentationType string               `json:"representationtype,omitempty"`
+	Profile            string               `json:"profile,omitempty"`
+	Version            string               `json:"version,omitempty"`
+	FirstCreated       *time.Time           `json:"firstcreated,omitempty"`
+	VersionCreated     *time.Time           `json:"versioncreated,omitempty"`
+	ContentCreated     *time.Time           `json:"contentcreated,omitempty"`
+	Embargoed          *time.Time           `json:"embargoed,omitempty"`
+	PubStatus          string               `json:"pubstatus,omitempty"`
+	Urgency            *int                 `json:"urgency,omitempty"`
+	CopyrightHolder    string               `json:"copyrightholder,omitempty"`
+	CopyrightNotice    string               `json:"copyrightnotice,omitempty"`
+	UsageTerms         string               `json:"usageterms,omitempty"`
+	EdNote             string               `json:"ednote,omitempty"`
+	Language           string               `json:"language,omitempty"`
+	Descriptions       []Text               `json:"descriptions,omitempty"`
+	Bodies             []Text               `json:"bodies,omitempty"`
+	Headlines          []Text               `json:"headlines,omitempty"`
+	People             []Entity             `json:"people,omitempty"`
+	Organisations      []Entity             `json:"organisations,omitempty"`
+	Places             []Entity             `json:"places,omitempty"`
+	Subjects           []Entity             `json:"subjects,omitempty"`
+	Events             []Entity             `json:"events,omitempty"`
+	Objects            []Entity             `json:"objects,omitempty"`
+	Genres             []Entity             `json:"genres,omitempty"`
+	Keywords           []string             `json:"keywords,omitempty"`
+	Title              string               `json:"title,omitempty"`
+	By                 string               `json:"by,omitempty"`
+	Slugline           string               `json:"slugline,omitempty"`
+	Located            string               `json:"located,omitempty"`
+	Renditions         map[string]Rendition `json:"renditions,omitempty"`
+	Associations       map[string]*Item     `json:"associations,omitempty"`
+	AltIDs             []AltID              `json:"altids,omitempty"`
+
+	// BodyHTML is the HTML body, it's used both when writing and
+	// reading ninjs. An HTML body in Bodies is only read if BodyHTML
+	// is empty.
+	BodyHTML string `json:"body_html,omitempty"`
+}
+
+// Text is a headline, description or body.
+type Text struct {
+	Role        string `json:"role,omitempty"`
+	ContentType string `json:"contenttype,omitempty"`
+	Value       string `json:"value"`
+}
+
+// Entity is a person, organisation, place, subject, event, object or
+// genre.
+type Entity struct {
+	Name    string `json:"name,omitempty"`
+	Rel     string `json:"rel,omitempty"`
+	URI     string `json:"uri,omitempty"`
+	Literal string `json:"literal,omitempty"`
+}
+
+// Rendition is a way to retrieve the item in a given format.
+type Rendition struct {
+	Href        string `json:"href,omitempty"`
+	ContentType string `json:"contenttype,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Height      int    `json:"height,omitempty"`
+	Width       int    `json:"width,omitempty"`
+}
+
+// AltID is an alternative identifier for the item.
+type AltID struct {
+	Role  string `json:"role,omitempty"`
+	Value string `json:"value"`
+}
+
+// Loss describes data that a conversion couldn't represent.
+type Loss struct {
+	// Path is a JSON pointer to the data in the source.
+	Path string
+	// Reason describes what happened to the data.
+	Reason string
+}
+
+func (l Loss) String() string {
+	return l.Path + ": " + l.Reason
+}
@@ -0,0 +1,658 @@
+package navigadoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// PatchOperation is a single RFC 6902 JSON Patch operation.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Patch is an RFC 6902 JSON Patch. Paths are resolved against the JSON
+// representation of doc.Document.
+type Patch []PatchOperation
+
+// PatchError is returned when a patch cannot be applied, or when the
+// patched document doesn't validate against the NavigaDoc schema.
+type PatchError struct {
+	// Index is the position of the failing operation in the patch, or
+	// -1 if the error couldn't be attributed to an operation.
+	Index int
+	Op    PatchOperation
+	Err   error
+}
+
+func (e PatchError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("patch: %v", e.Err)
+	}
+
+	return fmt.Sprintf("patch operation %d (%s %s): %v", e.Index, e.Op.Op, e.Op.Path, e.Err)
+}
+
+func (e PatchError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyPatch applies a JSON Patch to a copy of the document. The
+// result is validated against the NavigaDoc schema.
+func ApplyPatch(document *doc.Document, patch Patch) (*doc.Document, error) {
+	root, err := documentToJSONValue(document)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range patch {
+		root, err = applyPatchOperation(root, op)
+		if err != nil {
+			return nil, PatchError{Index: i, Op: op, Err: err}
+		}
+	}
+
+	return jsonValueToDocument(root, patch)
+}
+
+// ApplyMergePatch applies an RFC 7396 JSON Merge Patch to a copy of the
+// document. The result is validated against the NavigaDoc schema.
+func ApplyMergePatch(document *doc.Document, mergePatch []byte) (*doc.Document, error) {
+	root, err := documentToJSONValue(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var patch interface{}
+
+	err = json.Unmarshal(mergePatch, &patch)
+	if err != nil {
+		return nil, InvalidArgumentError{
+			Msg: fmt.Sprintf("invalid merge patch: %v", err),
+			Err: err,
+		}
+	}
+
+	return jsonValueToDocument(mergePatchValue(root, patch), nil)
+}
+
+// CreatePatch returns a JSON Patch that transforms a into b.
+func CreatePatch(a, b *doc.Document) (Patch, error) {
+	av, err := documentToJSONValue(a)
+	if err != nil {
+		return nil, err
+	}
+
+	bv, err := documentToJSONValue(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var patch Patch
+
+	err = diffJSONValues(&patch, "", av, bv)
+	if err != nil {
+		return nil, err
+	}
+
+	return patch, nil
+}
+
+// CreateMergePatch returns a JSON Merge Patch that transforms a into
+// b. Arrays are always replaced as a whole.
+func CreateMergePatch(a, b *doc.Document) ([]byte, error) {
+	av, err := documentToJSONValue(a)
+	if err != nil {
+		return nil, err
+	}
+
+	bv, err := documentToJSONValue(b)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := createMergePatchValue(av.(map[string]interface{}), bv.(map[string]interface{}))
+
+	return json.Marshal(patch)
+}
+
+func documentToJSONValue(document *doc.Document) (interface{}, error) {
+	if document == nil {
+		document = &doc.Document{}
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	var v interface{}
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal document: %w", err)
+	}
+
+	return v, nil
+}
+
+func jsonValueToDocument(v interface{}, patch Patch) (*doc.Document, error) {
+	result, err := NavigaDocJSONSchema.ValidateLoader(gojsonschema.NewGoLoader(v))
+	if err != nil {
+		return nil, PatchError{Index: -1, Err: fmt.Errorf("schema validation failed: %w", err)}
+	}
+
+	if !result.Valid() {
+		return nil, schemaPatchError(result.Errors(), patch)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, PatchError{Index: -1, Err: err}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var document doc.Document
+
+	err = dec.Decode(&document)
+	if err != nil {
+		return nil, PatchError{Index: -1, Err: err}
+	}
+
+	return &document, nil
+}
+
+// schemaPatchError attributes schema errors to the last operation that
+// touched the failing location, and reports the errors for the
+// earliest such operation.
+func schemaPatchError(errs []gojsonschema.ResultError, patch Patch) error {
+	indexes := make([]int, len(errs))
+	best := -1
+
+	for n, e := range errs {
+		pointer := schemaContextPointer(e.Context())
+		if prop, ok := e.Details()["property"].(string); ok && e.Type() == "required" {
+			pointer += "/" + escapePointerToken(prop)
+		}
+
+		indexes[n] = -1
+
+		for i, op := range patch {
+			if pointerHasPrefix(pointer, op.Path) || (pointer != "" && pointerHasPrefix(op.Path, pointer)) {
+				indexes[n] = i
+			}
+		}
+
+		if indexes[n] >= 0 && (best < 0 || indexes[n] < best) {
+			best = indexes[n]
+		}
+	}
+
+	var messages []string
+
+	for n, e := range errs {
+		if indexes[n] == best {
+			messages = append(messages, e.String())
+		}
+	}
+
+	pe := PatchError{
+		Index: best,
+		Err:   fmt.Errorf("schema validation failed: %s", strings.Join(messages, "; ")),
+	}
+
+	if best >= 0 {
+		pe.Op = patch[best]
+	}
+
+	return pe
+}
+
+// schemaContextPointer converts a gojsonschema context, f.ex.
+// "(root).links.0.uuid", to a JSON pointer.
+func schemaContextPointer(ctx *gojsonschema.JsonContext) string {
+	if ctx == nil {
+		return ""
+	}
+
+	parts := strings.Split(ctx.String(), ".")
+
+	var pointer strings.Builder
+
+	for _, p := range parts[1:] {
+		pointer.WriteString("/")
+		pointer.WriteString(escapePointerToken(p))
+	}
+
+	return pointer.String()
+}
+
+func pointerHasPrefix(pointer, prefix string) bool {
+	return prefix == "" || pointer == prefix || strings.HasPrefix(pointer, prefix+"/")
+}
+
+func escapePointerToken(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(tokens[i], "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func applyPatchOperation(root interface{}, op PatchOperation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return nil, fmt.Errorf("missing value")
+		}
+
+		err = json.Unmarshal(op.Value, &value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+	}
+
+	switch op.Op {
+	case "add":
+		return jsonAdd(root, path, value, false)
+	case "replace":
+		return jsonAdd(root, path, value, true)
+	case "remove":
+		root, _, err = jsonRemove(root, path)
+		return root, err
+	case "test":
+		current, err := jsonGet(root, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("test failed")
+		}
+
+		return root, nil
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		var v interface{}
+
+		if op.Op == "move" {
+			if op.From == "" {
+				return nil, fmt.Errorf("cannot move the document root")
+			}
+
+			if pointerHasPrefix(op.Path, op.From) && op.Path != op.From {
+				return nil, fmt.Errorf("cannot move a value into one of its children")
+			}
+
+			root, v, err = jsonRemove(root, from)
+		} else {
+			v, err = jsonGet(root, from)
+			if err == nil {
+				v, err = cloneJSONValue(v)
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return jsonAdd(root, path, v, false)
+	default:
+		return nil, fmt.Errorf("unknown operation %q", op.Op)
+	}
+}
+
+func cloneJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var c interface{}
+
+	err = json.Unmarshal(data, &c)
+
+	return c, err
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || (token != "0" && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	if idx > length || (!allowEnd && idx == length) {
+		return 0, fmt.Errorf("array index %d out of range", idx)
+	}
+
+	return idx, nil
+}
+
+func jsonGet(node interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			child, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("path not found: %q", token)
+			}
+
+			node = child
+		case []interface{}:
+			idx, err := arrayIndex(token, len(n), false)
+			if err != nil {
+				return nil, err
+			}
+
+			node = n[idx]
+		default:
+			return nil, fmt.Errorf("cannot traverse into %q", token)
+		}
+	}
+
+	return node, nil
+}
+
+// jsonAdd sets the value at path. Array elements are inserted unless
+// replace is set, in which case the target must already exist.
+func jsonAdd(node interface{}, path []string, value interface{}, replace bool) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token := path[0]
+	last := len(path) == 1
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[token]
+
+		if last {
+			if replace && !ok {
+				return nil, fmt.Errorf("path not found: %q", token)
+			}
+
+			n[token] = value
+
+			return n, nil
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("path not found: %q", token)
+		}
+
+		child, err := jsonAdd(child, path[1:], value, replace)
+		if err != nil {
+			return nil, err
+		}
+
+		n[token] = child
+
+		return n, nil
+	case []interface{}:
+		idx, err := arrayIndex(token, len(n), last && !replace)
+		if err != nil {
+			return nil, err
+		}
+
+		if !last {
+			n[idx], err = jsonAdd(n[idx], path[1:], value, replace)
+			return n, err
+		}
+
+		if replace {
+			n[idx] = value
+			return n, nil
+		}
+
+		n = append(n, nil)
+		copy(n[idx+1:], n[idx:])
+		n[idx] = value
+
+		return n, nil
+	default:
+		return nil, fmt.Errorf("cannot traverse into %q", token)
+	}
+}
+
+func jsonRemove(node interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the document root")
+	}
+
+	token := path[0]
+	last := len(path) == 1
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("path not found: %q", token)
+		}
+
+		if last {
+			delete(n, token)
+			return n, child, nil
+		}
+
+		child, removed, err := jsonRemove(child, path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		n[token] = child
+
+		return n, removed, nil
+	case []interface{}:
+		idx, err := arrayIndex(token, len(n), false)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if last {
+			removed := n[idx]
+			return append(n[:idx], n[idx+1:]...), removed, nil
+		}
+
+		child, removed, err := jsonRemove(n[idx], path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		n[idx] = child
+
+		return n, removed, nil
+	default:
+		return nil, nil, fmt.Errorf("cannot traverse into %q", token)
+	}
+}
+
+func mergePatchValue(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{})
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+
+		t[k] = mergePatchValue(t[k], v)
+	}
+
+	return t
+}
+
+func createMergePatchValue(a, b map[string]interface{}) map[string]interface{} {
+	patch := make(map[string]interface{})
+
+	for k, av := range a {
+		if _, ok := b[k]; !ok {
+			patch[k] = nil
+			continue
+		}
+
+		bv := b[k]
+		if reflect.DeepEqual(av, bv) {
+			continue
+		}
+
+		am, aIsMap := av.(map[string]interface{})
+		bm, bIsMap := bv.(map[string]interface{})
+
+		if aIsMap && bIsMap {
+			patch[k] = createMergePatchValue(am, bm)
+		} else {
+			patch[k] = bv
+		}
+	}
+
+	for k, bv := range b {
+		if _, ok := a[k]; !ok {
+			patch[k] = bv
+		}
+	}
+
+	return patch
+}
+
+func diffJSONValues(patch *Patch, path string, a, b interface{}) error {
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(av)+len(bv))
+
+		for k := range av {
+			keys = append(keys, k)
+		}
+
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			childPath := path + "/" + escapePointerToken(k)
+			aChild, inA := av[k]
+			bChild, inB := bv[k]
+
+			var err error
+
+			switch {
+			case !inB:
+				*patch = append(*patch, PatchOperation{Op: "remove", Path: childPath})
+			case !inA:
+				err = appendValueOp(patch, "add", childPath, bChild)
+			default:
+				err = diffJSONValues(patch, childPath, aChild, bChild)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+
+		common := len(av)
+		if len(bv) < common {
+			common = len(bv)
+		}
+
+		for i := 0; i < common; i++ {
+			err := diffJSONValues(patch, path+"/"+strconv.Itoa(i), av[i], bv[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		for i := len(av) - 1; i >= common; i-- {
+			*patch = append(*patch, PatchOperation{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+		}
+
+		for i := common; i < len(bv); i++ {
+			err := appendValueOp(patch, "add", path+"/"+strconv.Itoa(i), bv[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return appendValueOp(patch, "replace", path, b)
+}
+
+func appendValueOp(patch *Patch, op, path string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	*patch = append(*patch, PatchOperation{Op: op, Path: path, Value: data})
+
+	return nil
+}
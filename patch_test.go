@@ -0,0 +1,143 @@
+package navigadoc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+)
+
+func TestApplyPatch(t *testing.T) {
+	document := loadDocument(t, "./testdata/text.json")
+
+	var patch navigadoc.Patch
+
+	err := json.Unmarshal([]byte(`[
+		{"op": "test", "path": "/type", "value": "x-im/article"},
+		{"op": "replace", "path": "/title", "value": "Patched"},
+		{"op": "add", "path": "/content/0", "value": {"type": "x-im/preamble", "data": {"text": "Hi"}}},
+		{"op": "remove", "path": "/links/1"},
+		{"op": "move", "from": "/content/1", "path": "/content/-"},
+		{"op": "copy", "from": "/meta/0", "path": "/meta/-"},
+		{"op": "add", "path": "/content/6/links/0/data/width", "value": "100"}
+	]`), &patch)
+	must(t, err, "could not unmarshal patch")
+
+	patched, err := navigadoc.ApplyPatch(document, patch)
+	must(t, err, "could not apply patch")
+
+	if patched.Title != "Patched" {
+		t.Errorf("expected title to be patched, was %q", patched.Title)
+	}
+
+	if patched.Content[0].Type != "x-im/preamble" {
+		t.Errorf("expected a preamble to be inserted first, got %s", patched.Content[0].Type)
+	}
+
+	if last := patched.Content[len(patched.Content)-1]; last.Type != "x-im/header" {
+		t.Errorf("expected the header to be moved last, got %s", last.Type)
+	}
+
+	if len(patched.Links) != len(document.Links)-1 {
+		t.Errorf("expected one link to be removed")
+	}
+
+	if len(patched.Meta) != len(document.Meta)+1 {
+		t.Errorf("expected one meta block to be copied")
+	}
+
+	if patched.Content[6].Links[0].Data["width"] != "100" {
+		t.Errorf("expected nested data to be patched")
+	}
+
+	if document.Title == "Patched" {
+		t.Error("expected the original document to be left untouched")
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	document := loadDocument(t, "./testdata/text.json")
+
+	testCases := []struct {
+		patch string
+		index int
+	}{
+		{patch: `[{"op": "test", "path": "/type", "value": "x-im/image"}]`, index: 0},
+		{patch: `[{"op": "replace", "path": "/title", "value": "x"}, {"op": "remove", "path": "/nothing"}]`, index: 1},
+		{patch: `[{"op": "add", "path": "/links/100", "value": {}}]`, index: 0},
+		{patch: `[{"op": "add", "path": "/title", "value": "x"}, {"op": "replace", "path": "/links/0/uuid", "value": "not-a-uuid"}]`, index: 1},
+		{patch: `[{"op": "remove", "path": "/created"}, {"op": "replace", "path": "/title", "value": "x"}]`, index: 0},
+		{patch: `[{"op": "frobnicate", "path": "/title"}]`, index: 0},
+		{patch: `[{"op": "move", "from": "", "path": "/content/-"}]`, index: 0},
+		{patch: `[{"op": "move", "from": "", "path": ""}]`, index: 0},
+		{patch: `[{"op": "move", "from": "/content/0", "path": "/content/0/content/-"}]`, index: 0},
+	}
+
+	for _, tc := range testCases {
+		var patch navigadoc.Patch
+
+		err := json.Unmarshal([]byte(tc.patch), &patch)
+		must(t, err, "could not unmarshal patch")
+
+		_, err = navigadoc.ApplyPatch(document, patch)
+
+		var pe navigadoc.PatchError
+		if !errors.As(err, &pe) {
+			t.Errorf("%s: expected a patch error, got %v", tc.patch, err)
+			continue
+		}
+
+		if pe.Index != tc.index {
+			t.Errorf("%s: expected error for operation %d, got %d: %v", tc.patch, tc.index, pe.Index, err)
+		}
+	}
+}
+
+func TestCreatePatch(t *testing.T) {
+	a := loadDocument(t, "./testdata/text.json")
+	b := loadDocument(t, "./testdata/text.json")
+
+	b.Title = "Changed"
+	b.Content = b.Content[:3]
+	b.Links[0].Data = map[string]string{"key": "value"}
+	b.Meta[0].Data["score"] = "4"
+
+	patch, err := navigadoc.CreatePatch(a, b)
+	must(t, err, "could not create patch")
+
+	patched, err := navigadoc.ApplyPatch(a, patch)
+	must(t, err, "could not apply created patch")
+
+	if changes := navigadoc.Diff(b, patched); len(changes) != 0 {
+		t.Errorf("expected patched document to equal target, got:\n%s", changes)
+	}
+
+	mergePatch, err := navigadoc.CreateMergePatch(a, b)
+	must(t, err, "could not create merge patch")
+
+	merged, err := navigadoc.ApplyMergePatch(a, mergePatch)
+	must(t, err, "could not apply merge patch")
+
+	if changes := navigadoc.Diff(b, merged); len(changes) != 0 {
+		t.Errorf("expected merged document to equal target, got:\n%s", changes)
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	document := loadDocument(t, "./testdata/text.json")
+
+	merged, err := navigadoc.ApplyMergePatch(document, []byte(`{"title": "Merged", "source": null, "links": []}`))
+	must(t, err, "could not apply merge patch")
+
+	if merged.Title != "Merged" || merged.Source != "" || len(merged.Links) != 0 {
+		t.Errorf("unexpected merge result: %q %q %d", merged.Title, merged.Source, len(merged.Links))
+	}
+
+	_, err = navigadoc.ApplyMergePatch(document, []byte(`{"uuid": "nope"}`))
+
+	var pe navigadoc.PatchError
+	if !errors.As(err, &pe) {
+		t.Errorf("expected a schema error, got %v", err)
+	}
+}
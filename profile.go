@@ -0,0 +1,417 @@
+package navigadoc
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+const (
+	CodeMissingMeta    ErrorCode = "missing_meta"
+	CodeMissingData    ErrorCode = "missing_data"
+	CodeInvalidData    ErrorCode = "invalid_data"
+	CodeLinkNotAllowed ErrorCode = "link_not_allowed"
+)
+
+// DataType describes how a Block.Data value should be interpreted.
+type DataType int
+
+const (
+	DataString DataType = iota
+	DataInt
+	DataNumber
+	DataBool
+	// DataTime is an RFC3339 timestamp, with or without fractional
+	// seconds.
+	DataTime
+	// DataEnum is a string that must be one of DataRule.Values.
+	DataEnum
+)
+
+func (t DataType) String() string {
+	switch t {
+	case DataString:
+		return "string"
+	case DataInt:
+		return "int"
+	case DataNumber:
+		return "number"
+	case DataBool:
+		return "bool"
+	case DataTime:
+		return "time"
+	case DataEnum:
+		return "enum"
+	}
+
+	return "unknown"
+}
+
+// DataRule declares a key in Block.Data.
+type DataRule struct {
+	Key string
+	// Required keys must be present and non-empty.
+	Required bool
+	Type     DataType
+	// Values are the allowed values for DataEnum.
+	Values []string
+}
+
+// MetaRule declares a meta block on the document.
+type MetaRule struct {
+	Type     string
+	Required bool
+	Data     []DataRule
+}
+
+// LinkRule allows links with a rel, optionally restricted to a set of
+// target types.
+type LinkRule struct {
+	Rel string
+	// Types are the allowed link types, an empty list allows any type.
+	Types []string
+}
+
+// Profile holds the validation rules for a document type.
+type Profile struct {
+	Type string
+	Meta []MetaRule
+	// Links are the allowed links, a nil list allows any link.
+	Links []LinkRule
+}
+
+// Validate checks the document against the profile. The returned error
+// is a ValidationErrors.
+func (p Profile) Validate(document *doc.Document) error {
+	var errs ValidationErrors
+
+	for _, rule := range p.Meta {
+		found := false
+
+		for i := range document.Meta {
+			if document.Meta[i].Type != rule.Type {
+				continue
+			}
+
+			found = true
+			pointer := "/meta/" + strconv.Itoa(i)
+
+			errs = append(errs, validateData(document.Meta[i], pointer, rule.Data)...)
+		}
+
+		if !found && rule.Required {
+			errs = append(errs, &ValidationError{
+				Pointer: "/meta",
+				Code:    CodeMissingMeta,
+				Err:     fmt.Errorf("missing meta block %q", rule.Type),
+			})
+		}
+	}
+
+	if p.Links == nil {
+		return errs.Err()
+	}
+
+	for i, link := range document.Links {
+		if p.linkAllowed(link) {
+			continue
+		}
+
+		errs = append(errs, &ValidationError{
+			Pointer:   "/links/" + strconv.Itoa(i),
+			Code:      CodeLinkNotAllowed,
+			BlockType: link.Type,
+			BlockID:   link.ID,
+			Err: fmt.Errorf("link with rel %q and type %q is not allowed in %s",
+				link.Rel, link.Type, p.Type),
+		})
+	}
+
+	return errs.Err()
+}
+
+func (p Profile) linkAllowed(link doc.Block) bool {
+	for _, rule := range p.Links {
+		if rule.Rel != link.Rel {
+			continue
+		}
+
+		if len(rule.Types) == 0 {
+			return true
+		}
+
+		for _, t := range rule.Types {
+			if t == link.Type {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func validateData(block doc.Block, pointer string, rules []DataRule) ValidationErrors {
+	var errs ValidationErrors
+
+	for _, rule := range rules {
+		keyPointer := pointer + "/data/" + escapePointerToken(rule.Key)
+		value := block.Data[rule.Key]
+
+		if value == "" {
+			if rule.Required {
+				errs = append(errs, &ValidationError{
+					Pointer:   keyPointer,
+					Code:      CodeMissingData,
+					BlockType: block.Type,
+					BlockID:   block.ID,
+					Err:       fmt.Errorf("missing data %q", rule.Key),
+				})
+			}
+
+			continue
+		}
+
+		err := checkDataValue(&block, rule)
+		if err != nil {
+			errs = append(errs, &ValidationError{
+				Pointer:   keyPointer,
+				Code:      CodeInvalidData,
+				BlockType: block.Type,
+				BlockID:   block.ID,
+				Err:       doc.WithDataPath(err, pointer),
+			})
+		}
+	}
+
+	return errs
+}
+
+// checkDataValue parses the value with the doc.Block accessor for the
+// rule type, so that profiles accept the same values as the accessors.
+func checkDataValue(block *doc.Block, rule DataRule) error {
+	var err error
+
+	switch rule.Type {
+	case DataString:
+	case DataInt:
+		_, err = block.ParseInt(rule.Key)
+	case DataNumber:
+		_, err = block.ParseFloat(rule.Key)
+	case DataBool:
+		_, err = block.ParseBool(rule.Key)
+	case DataTime:
+		_, err = block.ParseTime(rule.Key)
+	case DataEnum:
+		_, err = block.ParseEnum(rule.Key, rule.Values...)
+	default:
+		err = fmt.Errorf("data %q: unknown data type", rule.Key)
+	}
+
+	return err
+}
+
+// ProfileRegistry holds profiles keyed on document type. It's safe for
+// concurrent use.
+type ProfileRegistry struct {
+	m        sync.RWMutex
+	profiles map[string]Profile
+}
+
+// NewProfileRegistry creates a registry with the given profiles.
+func NewProfileRegistry(profiles ...Profile) *ProfileRegistry {
+	r := ProfileRegistry{
+		profiles: make(map[string]Profile),
+	}
+
+	for i := range profiles {
+		r.Register(profiles[i])
+	}
+
+	return &r
+}
+
+// Register adds a profile, replacing any existing profile for the type.
+func (r *ProfileRegistry) Register(p Profile) {
+	r.m.Lock()
+	r.profiles[p.Type] = p
+	r.m.Unlock()
+}
+
+// Lookup returns the profile for a document type.
+func (r *ProfileRegistry) Lookup(docType string) (Profile, bool) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	p, ok := r.profiles[docType]
+
+	return p, ok
+}
+
+// Types returns the registered document types in sorted order.
+func (r *ProfileRegistry) Types() []string {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	types := make([]string, 0, len(r.profiles))
+	for t := range r.profiles {
+		types = append(types, t)
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+// Validate checks the document against the profile for its type.
+// Documents without a registered profile are considered valid.
+func (r *ProfileRegistry) Validate(document *doc.Document) error {
+	if document == nil {
+		return ValidationErrors{{Code: CodeEmptyDocument, Err: ErrEmptyDoc}}
+	}
+
+	p, ok := r.Lookup(document.Type)
+	if !ok {
+		return nil
+	}
+
+	return p.Validate(document)
+}
+
+// DefaultProfiles is the registry used by ValidateProfile and
+// RegisterProfile, it contains the built-in profiles.
+var DefaultProfiles = NewProfileRegistry(BuiltinProfiles()...)
+
+// RegisterProfile adds a profile to DefaultProfiles.
+func RegisterProfile(p Profile) {
+	DefaultProfiles.Register(p)
+}
+
+// ValidateProfile checks the document against its profile in
+// DefaultProfiles.
+func ValidateProfile(document *doc.Document) error {
+	return DefaultProfiles.Validate(document)
+}
+
+// BuiltinProfiles returns the profiles for the standard document types,
+// covering every document type in the test data and examples.
+func BuiltinProfiles() []Profile {
+	return []Profile{
+		{
+			Type: "x-im/article",
+			Meta: []MetaRule{
+				{Type: "x-im/newsvalue", Data: []DataRule{
+					{Key: "score", Type: DataInt},
+					{Key: "duration", Type: DataInt},
+					{Key: "end", Type: DataTime},
+				}},
+			},
+		},
+		{
+			Type: "x-im/image",
+			Meta: []MetaRule{
+				{Type: "x-im/image", Required: true, Data: []DataRule{
+					{Key: "width", Required: true, Type: DataInt},
+					{Key: "height", Required: true, Type: DataInt},
+					{Key: "mimeType", Required: true},
+				}},
+			},
+		},
+		{
+			Type: "x-im/pdf",
+			Meta: []MetaRule{
+				{Type: "x-im/pdf", Required: true, Data: []DataRule{
+					{Key: "mimeType", Required: true},
+				}},
+			},
+		},
+		{
+			Type: "x-im/svg",
+			Meta: []MetaRule{
+				{Type: "x-im/svg", Required: true, Data: []DataRule{
+					{Key: "mimeType", Required: true, Type: DataEnum, Values: []string{"image/svg+xml", "application/svg+xml"}},
+				}},
+			},
+		},
+		{
+			Type: "x-im/assignment",
+			Meta: []MetaRule{
+				{Type: "x-im/assignment", Required: true, Data: []DataRule{
+					{Key: "start", Required: true, Type: DataTime},
+					{Key: "end", Required: true, Type: DataTime},
+				}},
+			},
+			Links: []LinkRule{
+				{Rel: "image", Types: []string{"x-im/image"}},
+				{Rel: "location", Types: []string{"x-geo/point"}},
+				{Rel: "assignee"},
+			},
+		},
+		{
+			Type: "x-im/newscoverage",
+			Meta: []MetaRule{
+				{Type: "x-im/newscoverage", Required: true, Data: []DataRule{
+					{Key: "priority", Required: true, Type: DataInt},
+					{Key: "start", Type: DataTime},
+					{Key: "end", Type: DataTime},
+					{Key: "dateGranularity", Type: DataEnum, Values: []string{"date", "datetime"}},
+				}},
+			},
+			Links: []LinkRule{
+				{Rel: "section"},
+				{Rel: "assignment"},
+				{Rel: "event", Types: []string{"x-im/event"}},
+				{Rel: "topic", Types: []string{"x-im/topic"}},
+				{Rel: "story", Types: []string{"x-im/story"}},
+			},
+		},
+		{
+			Type: "x-im/story",
+			Links: []LinkRule{
+				{Rel: "broader", Types: []string{"x-im/story"}},
+				{Rel: "same-as"},
+			},
+		},
+		{
+			Type: "x-im/category",
+			Links: []LinkRule{
+				{Rel: "broader", Types: []string{"x-im/category"}},
+				{Rel: "same-as"},
+			},
+		},
+		{
+			Type: "x-im/event",
+			Meta: []MetaRule{
+				{Type: "x-im/event", Required: true, Data: []DataRule{
+					{Key: "start", Required: true, Type: DataTime},
+					{Key: "end", Required: true, Type: DataTime},
+					{Key: "priority", Type: DataInt},
+					{Key: "dateGranularity", Type: DataEnum, Values: []string{"date", "datetime"}},
+				}},
+			},
+		},
+		{
+			Type: "x-im/list",
+			Meta: []MetaRule{
+				{Type: "x-im/list", Required: true, Data: []DataRule{
+					{Key: "limit", Type: DataInt},
+				}},
+			},
+			Links: []LinkRule{
+				{Rel: "channel", Types: []string{"x-im/channel"}},
+			},
+		},
+		{
+			Type: "x-im/package",
+			Meta: []MetaRule{
+				{Type: "x-im/package", Required: true},
+			},
+			Links: []LinkRule{
+				{Rel: "list", Types: []string{"x-im/list"}},
+				{Rel: "channel", Types: []string{"x-im/channel"}},
+			},
+		},
+	}
+}
@@ -0,0 +1,192 @@
+package navigadoc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+func TestValidateProfileTestdata(t *testing.T) {
+	tests := []TestData{
+		{json: "./testdata/text.json"},
+		{json: "./testdata/image.json"},
+		{json: "./testdata/image2.json"},
+		{json: "./testdata/ampersand-image.json"},
+		{json: "./testdata/assignment.json"},
+		{json: "./testdata/assignment-empty-date.json"},
+		{json: "./testdata/planningItem.json"},
+		{json: "./testdata/list.json"},
+		{json: "./testdata/package.json"},
+		{json: "./testdata/pdf.json"},
+		{json: "./testdata/custom-asset.json"},
+		{json: "./testdata/story-concept.json"},
+		{json: "./testdata/event.json"},
+		{json: "./examples/concept-invalid-xml.json"},
+	}
+
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.json, func(t *testing.T) {
+			data, err := ioutil.ReadFile(test.json)
+			must(t, err, "could not open testfile")
+
+			// event.json has comments
+			var document doc.Document
+			must(t, json.Unmarshal(stripComments(data), &document), "could not unmarshal doc")
+
+			err = navigadoc.ValidateProfile(&document)
+			if !test.expectError && err != nil {
+				t.Error(err)
+			} else if test.expectError && err == nil {
+				t.Error("error was expected")
+			}
+		})
+	}
+}
+
+// stripComments removes "//" line comments outside of strings.
+func stripComments(data []byte) []byte {
+	var (
+		out      []byte
+		inString bool
+		escaped  bool
+	)
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		switch {
+		case inString:
+			inString = escaped || c != '"'
+			escaped = !escaped && c == '\\'
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+
+			if i == len(data) {
+				continue
+			}
+
+			c = data[i]
+		}
+
+		out = append(out, c)
+	}
+
+	return out
+}
+
+func TestValidateProfileErrors(t *testing.T) {
+	document := loadDocument(t, "./testdata/image.json")
+	document.Meta[0].Data["width"] = "wide"
+	delete(document.Meta[0].Data, "mimeType")
+
+	err := navigadoc.ValidateProfile(document)
+
+	var verrs navigadoc.ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+
+	codes := make(map[string]navigadoc.ErrorCode)
+	causes := make(map[string]error)
+
+	for _, e := range verrs {
+		codes[e.Pointer] = e.Code
+		causes[e.Pointer] = e.Err
+	}
+
+	if codes["/meta/0/data/width"] != navigadoc.CodeInvalidData {
+		t.Errorf("expected invalid width, got %v", verrs)
+	}
+
+	if codes["/meta/0/data/mimeType"] != navigadoc.CodeMissingData {
+		t.Errorf("expected missing mimeType, got %v", verrs)
+	}
+
+	var derr *doc.DataError
+	if !errors.As(causes["/meta/0/data/width"], &derr) || derr.Path != "/meta/0" || derr.Key != "width" {
+		t.Errorf("expected a data error for /meta/0 width, got %v", verrs)
+	}
+
+	// Profiles accept the same values as the doc.Block accessors.
+	document = loadDocument(t, "./testdata/image.json")
+	document.Meta[0].Data["width"] = " 1536 "
+
+	if document.Meta[0].Int("width", 0) != 1536 {
+		t.Fatal("expected the accessor to trim the width")
+	}
+
+	must(t, navigadoc.ValidateProfile(document), "width with surrounding space")
+
+	assignment := loadDocument(t, "./testdata/assignment.json")
+	assignment.Meta[0].Data["start"] = ""
+
+	err = navigadoc.ValidateProfile(assignment)
+	if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Code != navigadoc.CodeMissingData {
+		t.Errorf("expected a single missing data error, got %v", err)
+	}
+
+	svg := loadDocument(t, "./testdata/custom-asset.json")
+	svg.Meta[0].Data["mimeType"] = "image/png"
+
+	err = navigadoc.ValidateProfile(svg)
+	if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Code != navigadoc.CodeInvalidData {
+		t.Errorf("expected a single invalid data error, got %v", err)
+	}
+
+	story := loadDocument(t, "./testdata/story-concept.json")
+	story.Links = append(story.Links, doc.Block{Type: "x-im/category", Rel: "broader"})
+
+	err = navigadoc.ValidateProfile(story)
+	if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Code != navigadoc.CodeLinkNotAllowed {
+		t.Errorf("expected a single link error, got %v", err)
+	}
+
+	category := loadDocument(t, "./examples/concept-invalid-xml.json")
+	category.Links[0].Type = "x-im/story"
+
+	err = navigadoc.ValidateProfile(category)
+	if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Code != navigadoc.CodeLinkNotAllowed {
+		t.Errorf("expected a single link error, got %v", err)
+	}
+
+	planning := loadDocument(t, "./testdata/planningItem.json")
+	planning.Links = append(planning.Links, doc.Block{Type: "x-im/article", Rel: "event"})
+
+	err = navigadoc.ValidateProfile(planning)
+	if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Code != navigadoc.CodeLinkNotAllowed {
+		t.Errorf("expected a single link error, got %v", err)
+	}
+}
+
+func TestProfileRegistry(t *testing.T) {
+	registry := navigadoc.NewProfileRegistry()
+	registry.Register(navigadoc.Profile{
+		Type: "x-im/svg",
+		Meta: []navigadoc.MetaRule{
+			{Type: "x-im/svg", Required: true, Data: []navigadoc.DataRule{
+				{Key: "mimeType", Type: navigadoc.DataEnum, Values: []string{"image/svg+xml", "application/svg+xml"}},
+			}},
+		},
+	})
+
+	document := loadDocument(t, "./testdata/custom-asset.json")
+	must(t, registry.Validate(document), "valid custom asset")
+
+	document.Meta = nil
+
+	err := registry.Validate(document)
+	if err == nil {
+		t.Fatal("expected missing meta error")
+	}
+
+	must(t, registry.Validate(loadDocument(t, "./testdata/text.json")), "document without profile")
+}
@@ -0,0 +1,343 @@
+// Package render renders the content of a document as semantic HTML.
+//
+// Every block type is rendered by a RenderFunc registered for it,
+// blocks without a registered renderer are rendered by the fallback
+// renderer. Text with data.format=html is trusted and written as is,
+// all other text is escaped.
+package render
+
+import (
+	"bytes"
+	"html"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// RenderFunc writes the HTML for a block. Nested blocks are rendered
+// through the renderer.
+type RenderFunc func(w io.Writer, block *doc.Block, r *Renderer) error
+
+// Renderer renders blocks using the render functions registered by
+// block type.
+type Renderer struct {
+	renderers map[string]RenderFunc
+	fallback  RenderFunc
+}
+
+// NewRenderer creates a renderer with the default render functions
+// for article content.
+func NewRenderer() *Renderer {
+	r := &Renderer{
+		renderers: make(map[string]RenderFunc),
+		fallback:  RenderUnknown,
+	}
+
+	r.Register("x-im/header", TextElement("h1", ""))
+	r.Register("x-im/subheadline", TextElement("h2", ""))
+	r.Register("x-im/paragraph", TextElement("p", ""))
+	r.Register("x-im/preamble", TextElement("p", "preamble"))
+	r.Register("x-im/preleadin", TextElement("p", "preleadin"))
+	r.Register("x-im/pagedateline", TextElement("p", "dateline"))
+	r.Register("x-im/madmansrow", TextElement("p", "madmansrow"))
+	r.Register("x-im/drophead", TextElement("p", "drophead"))
+	r.Register("x-im/blockquote", TextElement("blockquote", ""))
+	r.Register("x-im/list-item", TextElement("li", ""))
+	r.Register("x-im/unordered-list", RenderList("ul"))
+	r.Register("x-im/ordered-list", RenderList("ol"))
+	r.Register("x-im/content-part", RenderContentPart)
+	r.Register("x-im/image", RenderImage)
+	r.Register("x-im/htmlembed", RenderHTMLEmbed)
+
+	return r
+}
+
+// Register sets the render function for a block type, replacing any
+// earlier registration.
+func (r *Renderer) Register(blockType string, fn RenderFunc) {
+	r.renderers[blockType] = fn
+}
+
+// SetFallback sets the render function used for block types that have
+// no registered render function.
+func (r *Renderer) SetFallback(fn RenderFunc) {
+	r.fallback = fn
+}
+
+// Types returns the block types that have a registered render
+// function.
+func (r *Renderer) Types() []string {
+	types := make([]string, 0, len(r.renderers))
+	for t := range r.renderers {
+		types = append(types, t)
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+// RenderDocument writes the HTML for the content of a document.
+func (r *Renderer) RenderDocument(w io.Writer, document *doc.Document) error {
+	if document == nil {
+		return navigadoc.ErrEmptyDoc
+	}
+
+	return r.RenderBlocks(w, document.Content)
+}
+
+// RenderBlocks writes the HTML for a list of blocks.
+func (r *Renderer) RenderBlocks(w io.Writer, blocks []doc.Block) error {
+	for i := range blocks {
+		err := r.RenderBlock(w, &blocks[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RenderBlock writes the HTML for a single block.
+func (r *Renderer) RenderBlock(w io.Writer, block *doc.Block) error {
+	fn, ok := r.renderers[block.Type]
+	if !ok {
+		fn = r.fallback
+	}
+
+	if fn == nil {
+		return nil
+	}
+
+	return fn(w, block, r)
+}
+
+// HTML renders the content of a document with the default renderer.
+func HTML(document *doc.Document) (string, error) {
+	var buf bytes.Buffer
+
+	err := NewRenderer().RenderDocument(&buf, document)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// Text returns the text of a block as HTML. Text with the html format
+// is returned as is and everything else is escaped.
+func Text(block *doc.Block) string {
+	text := block.Data["text"]
+	if block.Data["format"] == "html" {
+		return text
+	}
+
+	return html.EscapeString(text)
+}
+
+// TextElement renders the text of a block in an element, with an
+// optional class.
+func TextElement(tag, class string) RenderFunc {
+	return func(w io.Writer, block *doc.Block, r *Renderer) error {
+		hw := newWriter(w)
+
+		hw.open(tag, "id", block.ID, "class", class)
+		hw.raw(Text(block))
+		hw.close(tag)
+
+		return hw.err
+	}
+}
+
+// RenderList renders a list, the list items are the content of the
+// block.
+func RenderList(tag string) RenderFunc {
+	return func(w io.Writer, block *doc.Block, r *Renderer) error {
+		hw := newWriter(w)
+
+		hw.open(tag, "id", block.ID)
+
+		if hw.err == nil {
+			hw.err = r.RenderBlocks(w, block.Content)
+		}
+
+		hw.close(tag)
+
+		return hw.err
+	}
+}
+
+// RenderContentPart renders a content part, f.ex. a fact box, as an
+// aside. The type link of the block is used as the subtype.
+func RenderContentPart(w io.Writer, block *doc.Block, r *Renderer) error {
+	hw := newWriter(w)
+
+	var subtype string
+
+	for _, l := range block.Links {
+		if l.Rel == "type" {
+			subtype = l.Type
+		}
+	}
+
+	hw.open("aside", "id", block.ID, "class", "content-part", "data-subtype", subtype)
+
+	if block.Title != "" {
+		hw.open("h3")
+		hw.text(block.Title)
+		hw.close("h3")
+	}
+
+	if subject := block.Data["subject"]; subject != "" {
+		hw.open("h4")
+		hw.text(subject)
+		hw.close("h4")
+	}
+
+	if text := block.Data["text"]; text != "" {
+		hw.open("p")
+		hw.raw(Text(block))
+		hw.close("p")
+	}
+
+	if hw.err == nil {
+		hw.err = r.RenderBlocks(w, block.Content)
+	}
+
+	hw.close("aside")
+
+	return hw.err
+}
+
+// RenderImage renders an image as a figure. The image data is read
+// from the self link when there is one, the source is the URL of the
+// image or its URI if it has no URL.
+func RenderImage(w io.Writer, block *doc.Block, r *Renderer) error {
+	image := block
+
+	for i := range block.Links {
+		if block.Links[i].Rel == "self" {
+			image = &block.Links[i]
+			break
+		}
+	}
+
+	src := image.URL
+	if src == "" {
+		src = image.URI
+	}
+
+	var credits []string
+
+	for _, l := range image.Links {
+		if l.Rel == "author" && l.Title != "" {
+			credits = append(credits, l.Title)
+		}
+	}
+
+	hw := newWriter(w)
+
+	hw.open("figure", "id", block.ID)
+	hw.open("img",
+		"src", src,
+		"alt", image.Data["alttext"],
+		"width", image.Data["width"],
+		"height", image.Data["height"])
+
+	caption := Text(image)
+
+	if caption != "" || len(credits) > 0 {
+		hw.open("figcaption")
+		hw.raw(caption)
+
+		if len(credits) > 0 {
+			hw.open("span", "class", "credit")
+			hw.text(strings.Join(credits, ", "))
+			hw.close("span")
+		}
+
+		hw.close("figcaption")
+	}
+
+	hw.close("figure")
+
+	return hw.err
+}
+
+// RenderHTMLEmbed writes the embedded HTML as is.
+func RenderHTMLEmbed(w io.Writer, block *doc.Block, r *Renderer) error {
+	hw := newWriter(w)
+	hw.raw(block.Data["text"])
+
+	return hw.err
+}
+
+// RenderUnknown renders a block of an unknown type as a div with the
+// block type as a data attribute. The text and the content of the
+// block are rendered inside it.
+func RenderUnknown(w io.Writer, block *doc.Block, r *Renderer) error {
+	hw := newWriter(w)
+
+	hw.open("div", "id", block.ID, "data-type", block.Type)
+
+	if _, ok := block.Data["text"]; ok {
+		hw.raw(Text(block))
+	}
+
+	if hw.err == nil {
+		hw.err = r.RenderBlocks(w, block.Content)
+	}
+
+	hw.close("div")
+
+	return hw.err
+}
+
+// writer writes HTML and keeps the first error.
+type writer struct {
+	w   io.Writer
+	err error
+}
+
+func newWriter(w io.Writer) *writer {
+	return &writer{w: w}
+}
+
+func (hw *writer) raw(s string) {
+	if hw.err != nil || s == "" {
+		return
+	}
+
+	_, hw.err = io.WriteString(hw.w, s)
+}
+
+func (hw *writer) text(s string) {
+	hw.raw(html.EscapeString(s))
+}
+
+// open writes a start tag, attributes are given as name and value
+// pairs and empty values are skipped.
+func (hw *writer) open(tag string, attrs ...string) {
+	var b strings.Builder
+
+	b.WriteString("<" + tag)
+
+	for i := 0; i+1 < len(attrs); i += 2 {
+		if attrs[i+1] == "" {
+			continue
+		}
+
+		b.WriteString(" " + attrs[i] + `="` + html.EscapeString(attrs[i+1]) + `"`)
+	}
+
+	b.WriteString(">")
+
+	hw.raw(b.String())
+}
+
+func (hw *writer) close(tag string) {
+	hw.raw("</" + tag + ">")
+}
@@ -0,0 +1,130 @@
+package render_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/testutil"
+	"github.com/navigacontentlab/navigadoc/render"
+)
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		file     string
+		expected []string
+	}{
+		{
+			file: "../testdata/objecttexttocontent.json",
+			expected: []string{
+				`<h1 id="d0dbf67d385e">Lorem ipsum dolor sit</h1>`,
+				`<aside id="MTU0LDE0MywyMTQsMTgw" class="content-part" data-subtype="x-im/fact-1">` +
+					`<h3>Vivamus vitae gravida</h3>`,
+				`<p id="paragraph-abb247cabe3778f5296f3b65aa3c3cbb">Etiam <em id="emphasis-8734116999568b91e80e5c4e0453e117">`,
+				`</p></aside>`,
+			},
+		},
+		{
+			file: "../testdata/text.json",
+			expected: []string{
+				`<div id="8a5ef068ef18" data-type="leadin">Quisque dignissim molestie tellus</div>`,
+				`<p id="fafbedf02da1">Mauris eleifend, <a href="http://google.com"`,
+				`<figure id="dcc7c5fcf709"><img src="im://image/znX8U1C123JLDjlksdfgb40_jIka.jpeg" width="3560" height="2695">` +
+					`<figcaption>Vivamus luctus eros.<span class="credit">Jane Doe</span></figcaption></figure>`,
+				`<h4>Fact</h4>`,
+				// Text without the html format is escaped
+				`Etiam &lt;em id=&#34;emphasis-8734116999568b91e80e5c4e0453e117&#34;&gt;`,
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.file, func(t *testing.T) {
+			html, err := render.HTML(testutil.LoadDocument(t, test.file))
+			testutil.Must(t, err, "could not render document")
+
+			for _, e := range test.expected {
+				if !strings.Contains(html, e) {
+					t.Errorf("expected %q in:\n%s", e, html)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderList(t *testing.T) {
+	block := doc.Block{
+		Type: "x-im/unordered-list",
+		Content: []doc.Block{
+			{Type: "x-im/list-item", Data: map[string]string{"text": "Salt & pepper"}},
+			{Type: "x-im/list-item", Data: map[string]string{"text": "<em>Oil</em>", "format": "html"}},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := render.NewRenderer().RenderBlock(&buf, &block)
+	testutil.Must(t, err, "could not render list")
+
+	expected := `<ul><li>Salt &amp; pepper</li><li><em>Oil</em></li></ul>`
+	if buf.String() != expected {
+		t.Errorf("expected %s, got %s", expected, buf.String())
+	}
+}
+
+func TestRegister(t *testing.T) {
+	r := render.NewRenderer()
+
+	r.Register("x-im/paragraph", func(w io.Writer, block *doc.Block, r *render.Renderer) error {
+		_, err := fmt.Fprintf(w, "<p class=\"custom\">%s</p>", render.Text(block))
+		return err
+	})
+
+	r.SetFallback(nil)
+
+	document := doc.Document{
+		Content: []doc.Block{
+			{Type: "x-im/paragraph", Data: map[string]string{"text": "A & B"}},
+			{Type: "x-im/unknown", Data: map[string]string{"text": "skipped"}},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := r.RenderDocument(&buf, &document)
+	testutil.Must(t, err, "could not render document")
+
+	expected := `<p class="custom">A &amp; B</p>`
+	if buf.String() != expected {
+		t.Errorf("expected %s, got %s", expected, buf.String())
+	}
+}
+
+func TestRenderError(t *testing.T) {
+	r := render.NewRenderer()
+
+	failure := errors.New("failed")
+
+	r.Register("x-im/paragraph", func(w io.Writer, block *doc.Block, r *render.Renderer) error {
+		return failure
+	})
+
+	document := testutil.LoadDocument(t, "../testdata/objecttexttocontent.json")
+
+	err := r.RenderDocument(ioutil.Discard, document)
+	if !errors.Is(err, failure) {
+		t.Errorf("expected the render error, got %v", err)
+	}
+
+	_, err = render.HTML(nil)
+	if !errors.Is(err, navigadoc.ErrEmptyDoc) {
+		t.Errorf("expected ErrEmptyDoc, got %v", err)
+	}
+}
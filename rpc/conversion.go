@@ -1,14 +1,19 @@
-// Code generated by doc-generator. DO NOT EDIT.
+// Code generated by protoc-gen-navigadoc. DO NOT EDIT.
+
 package rpc
 
 import (
-	"time"
-
+	fmt "fmt"
 	doc "github.com/navigacontentlab/navigadoc/doc"
-	"google.golang.org/protobuf/types/known/timestamppb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	time "time"
 )
 
+// FromDocDocument sets the message from a doc.Document.
 func (d *Document) FromDocDocument(doc *doc.Document) error {
+	var err error
+
+	d.EmptyCollections = nil
 	d.Uuid = doc.UUID
 	d.Type = doc.Type
 	d.Uri = doc.URI
@@ -16,27 +21,81 @@ func (d *Document) FromDocDocument(doc *doc.Document) error {
 	d.Title = doc.Title
 	d.Path = doc.Path
 	d.Products = doc.Products
-	d.Created = fromDocTime(doc.Created)
-	d.Modified = fromDocTime(doc.Modified)
-	d.Published = fromDocTime(doc.Published)
-	d.Content = fromDocBlocks(doc.Content)
-	d.Meta = fromDocBlocks(doc.Meta)
-	d.Links = fromDocBlocks(doc.Links)
-	d.Properties = fromDocProperties(doc.Properties)
+	if doc.Products != nil && len(doc.Products) == 0 {
+		d.EmptyCollections = append(d.EmptyCollections, "products")
+	}
+	d.Created, err = fromDocTime(doc.Created)
+	if err != nil {
+		return fmt.Errorf("created: %w", err)
+	}
+	d.CreatedOffset = docTimeOffset(doc.Created)
+	d.Modified, err = fromDocTime(doc.Modified)
+	if err != nil {
+		return fmt.Errorf("modified: %w", err)
+	}
+	d.ModifiedOffset = docTimeOffset(doc.Modified)
+	d.Published, err = fromDocTime(doc.Published)
+	if err != nil {
+		return fmt.Errorf("published: %w", err)
+	}
+	d.PublishedOffset = docTimeOffset(doc.Published)
+	d.Content, err = fromDocBlocks(doc.Content)
+	if err != nil {
+		return fmt.Errorf("content: %w", err)
+	}
+	if doc.Content != nil && len(doc.Content) == 0 {
+		d.EmptyCollections = append(d.EmptyCollections, "content")
+	}
+	d.Meta, err = fromDocBlocks(doc.Meta)
+	if err != nil {
+		return fmt.Errorf("meta: %w", err)
+	}
+	if doc.Meta != nil && len(doc.Meta) == 0 {
+		d.EmptyCollections = append(d.EmptyCollections, "meta")
+	}
+	d.Links, err = fromDocBlocks(doc.Links)
+	if err != nil {
+		return fmt.Errorf("links: %w", err)
+	}
+	if doc.Links != nil && len(doc.Links) == 0 {
+		d.EmptyCollections = append(d.EmptyCollections, "links")
+	}
+	d.Properties, err = fromDocProperties(doc.Properties)
+	if err != nil {
+		return fmt.Errorf("properties: %w", err)
+	}
+	if doc.Properties != nil && len(doc.Properties) == 0 {
+		d.EmptyCollections = append(d.EmptyCollections, "properties")
+	}
 	d.Source = doc.Source
 	d.Language = doc.Language
 	d.Status = doc.Status
-	d.Unpublished = fromDocTime(doc.Unpublished)
+	d.Unpublished, err = fromDocTime(doc.Unpublished)
+	if err != nil {
+		return fmt.Errorf("unpublished: %w", err)
+	}
+	d.UnpublishedOffset = docTimeOffset(doc.Unpublished)
 	d.Provider = doc.Provider
 	return nil
 }
+
+// FromDocProperty sets the message from a doc.Property.
 func (d *Property) FromDocProperty(doc *doc.Property) error {
+	d.EmptyCollections = nil
 	d.Name = doc.Name
 	d.Value = doc.Value
 	d.Parameters = doc.Parameters
+	if doc.Parameters != nil && len(doc.Parameters) == 0 {
+		d.EmptyCollections = append(d.EmptyCollections, "parameters")
+	}
 	return nil
 }
+
+// FromDocBlock sets the message from a doc.Block.
 func (d *Block) FromDocBlock(doc *doc.Block) error {
+	var err error
+
+	d.EmptyCollections = nil
 	d.Id = doc.ID
 	d.Uuid = doc.UUID
 	d.Uri = doc.URI
@@ -44,17 +103,42 @@ func (d *Block) FromDocBlock(doc *doc.Block) error {
 	d.Type = doc.Type
 	d.Title = doc.Title
 	d.Data = doc.Data
+	if doc.Data != nil && len(doc.Data) == 0 {
+		d.EmptyCollections = append(d.EmptyCollections, "data")
+	}
 	d.Rel = doc.Rel
 	d.Name = doc.Name
 	d.Value = doc.Value
 	d.ContentType = doc.ContentType
-	d.Links = fromDocBlocks(doc.Links)
-	d.Content = fromDocBlocks(doc.Content)
-	d.Meta = fromDocBlocks(doc.Meta)
+	d.Links, err = fromDocBlocks(doc.Links)
+	if err != nil {
+		return fmt.Errorf("links: %w", err)
+	}
+	if doc.Links != nil && len(doc.Links) == 0 {
+		d.EmptyCollections = append(d.EmptyCollections, "links")
+	}
+	d.Content, err = fromDocBlocks(doc.Content)
+	if err != nil {
+		return fmt.Errorf("content: %w", err)
+	}
+	if doc.Content != nil && len(doc.Content) == 0 {
+		d.EmptyCollections = append(d.EmptyCollections, "content")
+	}
+	d.Meta, err = fromDocBlocks(doc.Meta)
+	if err != nil {
+		return fmt.Errorf("meta: %w", err)
+	}
+	if doc.Meta != nil && len(doc.Meta) == 0 {
+		d.EmptyCollections = append(d.EmptyCollections, "meta")
+	}
 	d.Role = doc.Role
 	return nil
 }
+
+// ToDocDocument sets the fields of a doc.Document from the message.
 func (d *Document) ToDocDocument(doc *doc.Document) error {
+	var err error
+
 	doc.UUID = d.Uuid
 	doc.Type = d.Type
 	doc.URI = d.Uri
@@ -62,27 +146,99 @@ func (d *Document) ToDocDocument(doc *doc.Document) error {
 	doc.Title = d.Title
 	doc.Path = d.Path
 	doc.Products = d.Products
-	doc.Created = toDocTime(d.Created)
-	doc.Modified = toDocTime(d.Modified)
-	doc.Published = toDocTime(d.Published)
-	doc.Content = toDocBlocks(d.Content)
-	doc.Meta = toDocBlocks(d.Meta)
-	doc.Links = toDocBlocks(d.Links)
-	doc.Properties = toDocProperties(d.Properties)
+	if len(doc.Products) == 0 {
+		doc.Products = nil
+
+		if isEmptyCollection(d.EmptyCollections, "products") {
+			doc.Products = emptyDocStrings()
+		}
+	}
+	doc.Created, err = toDocTimeInOffset(d.Created, d.CreatedOffset)
+	if err != nil {
+		return fmt.Errorf("created: %w", err)
+	}
+	doc.Modified, err = toDocTimeInOffset(d.Modified, d.ModifiedOffset)
+	if err != nil {
+		return fmt.Errorf("modified: %w", err)
+	}
+	doc.Published, err = toDocTimeInOffset(d.Published, d.PublishedOffset)
+	if err != nil {
+		return fmt.Errorf("published: %w", err)
+	}
+	doc.Content, err = toDocBlocks(d.Content)
+	if err != nil {
+		return fmt.Errorf("content: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = nil
+
+		if isEmptyCollection(d.EmptyCollections, "content") {
+			doc.Content = emptyDocBlocks()
+		}
+	}
+	doc.Meta, err = toDocBlocks(d.Meta)
+	if err != nil {
+		return fmt.Errorf("meta: %w", err)
+	}
+	if len(doc.Meta) == 0 {
+		doc.Meta = nil
+
+		if isEmptyCollection(d.EmptyCollections, "meta") {
+			doc.Meta = emptyDocBlocks()
+		}
+	}
+	doc.Links, err = toDocBlocks(d.Links)
+	if err != nil {
+		return fmt.Errorf("links: %w", err)
+	}
+	if len(doc.Links) == 0 {
+		doc.Links = nil
+
+		if isEmptyCollection(d.EmptyCollections, "links") {
+			doc.Links = emptyDocBlocks()
+		}
+	}
+	doc.Properties, err = toDocProperties(d.Properties)
+	if err != nil {
+		return fmt.Errorf("properties: %w", err)
+	}
+	if len(doc.Properties) == 0 {
+		doc.Properties = nil
+
+		if isEmptyCollection(d.EmptyCollections, "properties") {
+			doc.Properties = emptyDocProperties()
+		}
+	}
 	doc.Source = d.Source
 	doc.Language = d.Language
 	doc.Status = d.Status
-	doc.Unpublished = toDocTime(d.Unpublished)
+	doc.Unpublished, err = toDocTimeInOffset(d.Unpublished, d.UnpublishedOffset)
+	if err != nil {
+		return fmt.Errorf("unpublished: %w", err)
+	}
 	doc.Provider = d.Provider
 	return nil
 }
+
+// ToDocProperty sets the fields of a doc.Property from the message.
 func (d *Property) ToDocProperty(doc *doc.Property) error {
 	doc.Name = d.Name
 	doc.Value = d.Value
 	doc.Parameters = d.Parameters
+	if len(doc.Parameters) == 0 {
+		doc.Parameters = nil
+
+		if isEmptyCollection(d.EmptyCollections, "parameters") {
+			doc.Parameters = emptyDocStringMap()
+		}
+	}
 	return nil
 }
+
+// ToDocBlock sets the fields of a doc.Block from the message.
 func (d *Block) ToDocBlock(doc *doc.Block) error {
+	var err error
+
 	doc.ID = d.Id
 	doc.UUID = d.Uuid
 	doc.URI = d.Uri
@@ -90,69 +246,275 @@ func (d *Block) ToDocBlock(doc *doc.Block) error {
 	doc.Type = d.Type
 	doc.Title = d.Title
 	doc.Data = d.Data
+	if len(doc.Data) == 0 {
+		doc.Data = nil
+
+		if isEmptyCollection(d.EmptyCollections, "data") {
+			doc.Data = emptyDocStringMap()
+		}
+	}
 	doc.Rel = d.Rel
 	doc.Name = d.Name
 	doc.Value = d.Value
 	doc.ContentType = d.ContentType
-	doc.Links = toDocBlocks(d.Links)
-	doc.Content = toDocBlocks(d.Content)
-	doc.Meta = toDocBlocks(d.Meta)
+	doc.Links, err = toDocBlocks(d.Links)
+	if err != nil {
+		return fmt.Errorf("links: %w", err)
+	}
+	if len(doc.Links) == 0 {
+		doc.Links = nil
+
+		if isEmptyCollection(d.EmptyCollections, "links") {
+			doc.Links = emptyDocBlocks()
+		}
+	}
+	doc.Content, err = toDocBlocks(d.Content)
+	if err != nil {
+		return fmt.Errorf("content: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = nil
+
+		if isEmptyCollection(d.EmptyCollections, "content") {
+			doc.Content = emptyDocBlocks()
+		}
+	}
+	doc.Meta, err = toDocBlocks(d.Meta)
+	if err != nil {
+		return fmt.Errorf("meta: %w", err)
+	}
+	if len(doc.Meta) == 0 {
+		doc.Meta = nil
+
+		if isEmptyCollection(d.EmptyCollections, "meta") {
+			doc.Meta = emptyDocBlocks()
+		}
+	}
 	doc.Role = d.Role
 	return nil
 }
 
-func fromDocBlocks(blocks []doc.Block) []*Block {
-	var rpcBlocks []*Block
-	for _, b := range blocks {
-		rpcBlock := Block{}
-		rpcBlock.FromDocBlock(&b)
-		rpcBlocks = append(rpcBlocks, &rpcBlock)
+func fromDocTime(v *time.Time) (*timestamppb.Timestamp, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	t := timestamppb.New(*v)
+
+	err := t.CheckValid()
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func docTimeOffset(v *time.Time) int32 {
+	if v == nil {
+		return 0
+	}
+
+	_, offset := v.Zone()
+
+	return int32(offset)
+}
+
+func fromDocBlocks(s []doc.Block) ([]*Block, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	c := make([]*Block, len(s))
+
+	for i := range s {
+		v, err := fromDocBlock(&s[i])
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", i, err)
+		}
+
+		c[i] = v
 	}
-	return rpcBlocks
+
+	return c, nil
 }
 
-func toDocBlocks(blocks []*Block) []doc.Block {
-	var docBlocks []doc.Block
-	for _, b := range blocks {
-		docBlock := doc.Block{}
-		b.ToDocBlock(&docBlock)
-		docBlocks = append(docBlocks, docBlock)
+func fromDocProperties(s []doc.Property) ([]*Property, error) {
+	if s == nil {
+		return nil, nil
 	}
-	return docBlocks
+
+	c := make([]*Property, len(s))
+
+	for i := range s {
+		v, err := fromDocProperty(&s[i])
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", i, err)
+		}
+
+		c[i] = v
+	}
+
+	return c, nil
 }
 
-func fromDocProperties(properties []doc.Property) []*Property {
-	var rpcProperties []*Property
-	for _, p := range properties {
-		rpcProp := Property{}
-		rpcProp.FromDocProperty(&p)
-		rpcProperties = append(rpcProperties, &rpcProp)
+func isEmptyCollection(empty []string, name string) bool {
+	for i := range empty {
+		if empty[i] == name {
+			return true
+		}
 	}
-	return rpcProperties
+
+	return false
+}
+
+func emptyDocStrings() []string {
+	return make([]string, 0)
 }
 
-func toDocProperties(properties []*Property) []doc.Property {
-	var docProperties []doc.Property
-	for _, p := range properties {
-		docProp := doc.Property{}
-		p.ToDocProperty(&docProp)
-		docProperties = append(docProperties, docProp)
+func toDocTimeInOffset(v *timestamppb.Timestamp, offset int32) (*time.Time, error) {
+	t, err := toDocTime(v)
+	if err != nil || t == nil || offset == 0 {
+		return t, err
 	}
-	return docProperties
+
+	if offset <= -maxOffset || offset >= maxOffset {
+		return nil, fmt.Errorf("UTC offset %d is out of range", offset)
+	}
+
+	local := t.In(time.FixedZone("", int(offset)))
+
+	return &local, nil
 }
 
-func fromDocTime(ts *time.Time) *timestamppb.Timestamp {
-	if ts != nil {
-		time := timestamppb.New(*ts)
-		return time
+// maxOffset is the bound of UTC offsets in seconds, RFC 3339 offsets
+// are less than a day.
+const maxOffset = 24 * 60 * 60
+
+func toDocBlocks(s []*Block) ([]doc.Block, error) {
+	if s == nil {
+		return nil, nil
 	}
-	return nil
+
+	c := make([]doc.Block, len(s))
+
+	for i := range s {
+		v, err := toDocBlock(s[i])
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", i, err)
+		}
+
+		if v != nil {
+			c[i] = *v
+		}
+	}
+
+	return c, nil
+}
+
+func emptyDocBlocks() []doc.Block {
+	return make([]doc.Block, 0)
 }
 
-func toDocTime(ts *timestamppb.Timestamp) *time.Time {
-	if ts != nil {
-		time := ts.AsTime()
-		return &time
+func toDocProperties(s []*Property) ([]doc.Property, error) {
+	if s == nil {
+		return nil, nil
 	}
-	return nil
+
+	c := make([]doc.Property, len(s))
+
+	for i := range s {
+		v, err := toDocProperty(s[i])
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", i, err)
+		}
+
+		if v != nil {
+			c[i] = *v
+		}
+	}
+
+	return c, nil
+}
+
+func emptyDocProperties() []doc.Property {
+	return make([]doc.Property, 0)
+}
+
+func emptyDocStringMap() map[string]string {
+	return make(map[string]string, 0)
+}
+
+func fromDocBlock(v *doc.Block) (*Block, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	var m Block
+
+	err := m.FromDocBlock(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func fromDocProperty(v *doc.Property) (*Property, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	var m Property
+
+	err := m.FromDocProperty(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func toDocTime(v *timestamppb.Timestamp) (*time.Time, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	err := v.CheckValid()
+	if err != nil {
+		return nil, err
+	}
+
+	t := v.AsTime()
+
+	return &t, nil
+}
+
+func toDocBlock(v *Block) (*doc.Block, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	var m doc.Block
+
+	err := v.ToDocBlock(&m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func toDocProperty(v *Property) (*doc.Property, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	var m doc.Property
+
+	err := v.ToDocProperty(&m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
 }
@@ -0,0 +1,277 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/internal/testutil"
+	"github.com/navigacontentlab/navigadoc/rpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// roundTrip converts the document to a message, encodes and decodes
+// it, and converts it back.
+func roundTrip(document *doc.Document) (*doc.Document, error) {
+	var msg rpc.Document
+
+	err := msg.FromDocDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded rpc.Document
+
+	err = proto.Unmarshal(data, &decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var result doc.Document
+
+	err = decoded.ToDocDocument(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func TestRoundTripTestData(t *testing.T) {
+	files, err := filepath.Glob("../testdata/*.json")
+	testutil.Must(t, err, "failed to list test data")
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := ioutil.ReadFile(file)
+			testutil.Must(t, err, "could not open testfile")
+
+			if !json.Valid(data) {
+				t.Skip("not a JSON document")
+			}
+
+			var document doc.Document
+
+			err = json.Unmarshal(data, &document)
+			testutil.Must(t, err, "could not unmarshal doc")
+
+			result, err := roundTrip(&document)
+			testutil.Must(t, err, "failed to round trip document")
+
+			if !document.Equal(result) {
+				t.Error("expected the round tripped document to be equal")
+			}
+
+			want, err := json.Marshal(&document)
+			testutil.Must(t, err, "failed to marshal document")
+
+			got, err := json.Marshal(result)
+			testutil.Must(t, err, "failed to marshal round tripped document")
+
+			if string(got) != string(want) {
+				t.Errorf("expected the JSON to be unchanged\nwant: %s\ngot:  %s", want, got)
+			}
+		})
+	}
+}
+
+// randomDocument generates documents with timestamps in random time
+// zones, and with collections that are nil, empty or filled.
+type randomDocument struct {
+	doc.Document
+}
+
+func (randomDocument) Generate(r *rand.Rand, size int) reflect.Value {
+	d := doc.Document{
+		UUID:        "8c2f8c62-5bdf-4e0b-bb04-2a8df38ac9d4",
+		Type:        "x-im/article",
+		Title:       randomString(r),
+		Products:    randomStrings(r),
+		Created:     randomTime(r),
+		Modified:    randomTime(r),
+		Published:   randomTime(r),
+		Unpublished: randomTime(r),
+		Content:     randomBlocks(r, 2),
+		Meta:        randomBlocks(r, 2),
+		Links:       randomBlocks(r, 2),
+	}
+
+	switch r.Intn(3) {
+	case 1:
+		d.Properties = []doc.Property{}
+	case 2:
+		d.Properties = []doc.Property{{
+			Name:       randomString(r),
+			Value:      randomString(r),
+			Parameters: randomMap(r),
+		}}
+	}
+
+	return reflect.ValueOf(randomDocument{d})
+}
+
+func randomString(r *rand.Rand) string {
+	return []string{"", "a", "ö", "<b>&amp;</b>"}[r.Intn(4)]
+}
+
+func randomStrings(r *rand.Rand) []string {
+	switch r.Intn(3) {
+	case 0:
+		return nil
+	case 1:
+		return []string{}
+	}
+
+	return []string{randomString(r), randomString(r)}
+}
+
+func randomMap(r *rand.Rand) map[string]string {
+	switch r.Intn(3) {
+	case 0:
+		return nil
+	case 1:
+		return map[string]string{}
+	}
+
+	return map[string]string{"k": randomString(r)}
+}
+
+func randomTime(r *rand.Rand) *time.Time {
+	if r.Intn(4) == 0 {
+		return nil
+	}
+
+	// Offsets in quarter hours between -12:00 and +14:00.
+	zone := time.FixedZone("", (r.Intn(105)-48)*15*60)
+	t := time.Unix(r.Int63n(1<<34), r.Int63n(1e9)).In(zone)
+
+	return &t
+}
+
+func randomBlocks(r *rand.Rand, depth int) []doc.Block {
+	switch r.Intn(3) {
+	case 0:
+		return nil
+	case 1:
+		return []doc.Block{}
+	}
+
+	blocks := make([]doc.Block, 1+r.Intn(2))
+
+	for i := range blocks {
+		blocks[i] = doc.Block{
+			ID:    randomString(r),
+			Type:  "x-im/paragraph",
+			Title: randomString(r),
+			Data:  randomMap(r),
+		}
+
+		if depth > 0 {
+			blocks[i].Links = randomBlocks(r, depth-1)
+			blocks[i].Content = randomBlocks(r, depth-1)
+			blocks[i].Meta = randomBlocks(r, depth-1)
+		}
+	}
+
+	return blocks
+}
+
+func TestRoundTripProperty(t *testing.T) {
+	identity := func(d randomDocument) bool {
+		result, err := roundTrip(&d.Document)
+		if err != nil {
+			t.Logf("failed to round trip document: %v", err)
+			return false
+		}
+
+		want, err := json.Marshal(&d.Document)
+		if err != nil {
+			t.Logf("failed to marshal document: %v", err)
+			return false
+		}
+
+		got, err := json.Marshal(result)
+		if err != nil {
+			t.Logf("failed to marshal round tripped document: %v", err)
+			return false
+		}
+
+		return d.Document.Equal(result) && string(got) == string(want)
+	}
+
+	err := quick.Check(identity, &quick.Config{
+		MaxCount: 500,
+		Rand:     rand.New(rand.NewSource(1)),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRoundTripTimeZone(t *testing.T) {
+	var document doc.Document
+
+	err := json.Unmarshal([]byte(`{"published": "2017-02-22T09:22:07+01:00"}`), &document)
+	testutil.Must(t, err, "could not unmarshal doc")
+
+	result, err := roundTrip(&document)
+	testutil.Must(t, err, "failed to round trip document")
+
+	got := result.Published.Format(time.RFC3339)
+	if got != "2017-02-22T09:22:07+01:00" {
+		t.Errorf("expected the UTC offset to be kept, got %s", got)
+	}
+}
+
+func TestConversionErrors(t *testing.T) {
+	tooLate := time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err := new(rpc.Document).FromDocDocument(&doc.Document{Modified: &tooLate})
+	if err == nil || !strings.HasPrefix(err.Error(), "modified: ") {
+		t.Errorf("expected an error for the modified timestamp, got: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		msg    *rpc.Document
+		prefix string
+	}{
+		{
+			name: "invalid timestamp",
+			msg: &rpc.Document{
+				Created: &timestamppb.Timestamp{Nanos: 1e9},
+			},
+			prefix: "created: ",
+		},
+		{
+			name: "out of range offset",
+			msg: &rpc.Document{
+				Published:       timestamppb.Now(),
+				PublishedOffset: 24 * 60 * 60,
+			},
+			prefix: "published: UTC offset 86400 is out of range",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var document doc.Document
+
+			err := tc.msg.ToDocDocument(&document)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.prefix) {
+				t.Errorf("expected an error starting with %q, got: %v", tc.prefix, err)
+			}
+		})
+	}
+}
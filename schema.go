@@ -0,0 +1,113 @@
+package navigadoc
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Schema is a JSON schema that is compiled on first use and then
+// reused. It's safe for concurrent use.
+type Schema struct {
+	name   string
+	loader gojsonschema.JSONLoader
+
+	once     sync.Once
+	compiled *gojsonschema.Schema
+	err      error
+}
+
+// NewSchema creates a schema from its JSON source.
+func NewSchema(name string, source string) *Schema {
+	return &Schema{
+		name:   name,
+		loader: gojsonschema.NewStringLoader(source),
+	}
+}
+
+// NewSchemaFromLoader creates a schema from a gojsonschema loader, f.ex.
+// a reference loader for a schema on disk.
+func NewSchemaFromLoader(name string, loader gojsonschema.JSONLoader) *Schema {
+	return &Schema{
+		name:   name,
+		loader: loader,
+	}
+}
+
+var (
+	// NavigaDocJSONSchema is the generated NavigaDoc schema.
+	NavigaDocJSONSchema = NewSchema("navigadoc", NavigaDocSchema)
+	// CCAJSONSchema is the CCA document schema.
+	CCAJSONSchema = NewSchema("cca", CCASchema)
+)
+
+// Name returns the name of the schema.
+func (s *Schema) Name() string {
+	return s.name
+}
+
+// Compile returns the compiled schema, the schema is only compiled
+// once.
+func (s *Schema) Compile() (*gojsonschema.Schema, error) {
+	s.once.Do(func() {
+		s.compiled, s.err = gojsonschema.NewSchema(s.loader)
+		if s.err != nil {
+			s.err = fmt.Errorf("failed to compile schema %q: %w", s.name, s.err)
+		}
+	})
+
+	return s.compiled, s.err
+}
+
+// ValidateLoader validates a document. The result contains the schema
+// issues, a non-nil error indicates an error with the validator.
+func (s *Schema) ValidateLoader(document gojsonschema.JSONLoader) (*gojsonschema.Result, error) {
+	compiled, err := s.Compile()
+	if err != nil {
+		return nil, err
+	}
+
+	return compiled.Validate(document)
+}
+
+// ValidationOption configures Validate.
+type ValidationOption func(o *validationOptions)
+
+type validationOptions struct {
+	schemas []*Schema
+}
+
+// WithSchema validates against the given schemas instead of the
+// NavigaDoc schema. The option can be repeated to validate against
+// several schemas.
+func WithSchema(schemas ...*Schema) ValidationOption {
+	return func(o *validationOptions) {
+		o.schemas = append(o.schemas, schemas...)
+	}
+}
+
+// Validate validates a JSON document against the NavigaDoc schema, or
+// the schemas given by WithSchema. The returned ValidationErrors contain
+// the schema issues, a non-nil error indicates an error with the
+// validator.
+func Validate(document []byte, opts ...ValidationOption) (ValidationErrors, error) {
+	v, err := NewValidator(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.ValidateBytes(document)
+}
+
+// ValidateCCAJSON validates the document against the CCA schema
+// The error array contains schema issues as *ValidationError
+// A non-nil error indicates an error with the validator
+func ValidateCCAJSON(document string) ([]error, error) {
+	errs, err := Validate([]byte(document), WithSchema(CCAJSONSchema))
+	if err != nil {
+		return nil, err
+	}
+
+	return errs.list(), nil
+}
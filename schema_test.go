@@ -0,0 +1,128 @@
+package navigadoc_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+)
+
+func TestValidateCCAJSON(t *testing.T) {
+	tests := []TestData{
+		{json: "./examples/cca-example.json"},
+		{json: "./testdata/text.json", expectError: true},
+	}
+
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.json, func(t *testing.T) {
+			data, err := ioutil.ReadFile(test.json)
+			must(t, err, "could not open testfile")
+
+			errs, err := navigadoc.ValidateCCAJSON(string(data))
+			must(t, err, "failed to validate")
+
+			if !test.expectError && len(errs) > 0 {
+				t.Error(errs)
+			} else if test.expectError && len(errs) == 0 {
+				t.Error("error was expected")
+			}
+		})
+	}
+}
+
+func TestValidateCCAStrictness(t *testing.T) {
+	document := `{
+		"uuid": "8606660e-06d2-4ebe-bc3a-6c17cbfb6179",
+		"uri": "im://article/8606660e-06d2-4ebe-bc3a-6c17cbfb6179",
+		"type": "article",
+		"status": "published",
+		"created": "2017-02-22T08:12:40Z",
+		"modified": "2017-02-22T10:37:23Z",
+		"links": [{"type": "x-im/channel"}],
+		"meta": [{"id": "m1", "type": "x-im/image", "data": {"width": "1536"}}]
+	}`
+
+	errs, err := navigadoc.Validate([]byte(document), navigadoc.WithSchema(navigadoc.CCAJSONSchema))
+	must(t, err, "failed to validate")
+
+	pointers := make(map[string]bool)
+	for _, e := range errs {
+		pointers[e.Pointer] = true
+	}
+
+	for _, p := range []string{"/type", "/status", "/links/0/rel", "/meta/0/data/width"} {
+		if !pointers[p] {
+			t.Errorf("expected an error for %s, got %v", p, errs)
+		}
+	}
+
+	errs, err = navigadoc.Validate([]byte(document))
+	must(t, err, "failed to validate")
+
+	if len(errs) != 0 {
+		t.Errorf("expected document to be valid against the NavigaDoc schema, got %v", errs)
+	}
+}
+
+func TestValidateNavigaDocStrictness(t *testing.T) {
+	document := `{
+		"uuid": "8606660e-06d2-4ebe-bc3a-6c17cbfb6179",
+		"type": "x-im/article",
+		"created": "2017-02-22T08:12:40Z",
+		"headline": "Not a document field",
+		"properties": [{"value": "nameless"}],
+		"content": [{"type": "x-im/paragraph", "data": {"text": 42}}],
+		"links": [{"rel": "author", "uri": "not a uri"}]
+	}`
+
+	errs, err := navigadoc.Validate([]byte(document))
+	must(t, err, "failed to validate")
+
+	pointers := make(map[string]bool)
+	for _, e := range errs {
+		pointers[e.Pointer] = true
+	}
+
+	for _, p := range []string{"", "/properties/0/name", "/content/0/data/text", "/links/0/uri"} {
+		if !pointers[p] {
+			t.Errorf("expected an error for %s, got %v", p, errs)
+		}
+	}
+}
+
+func TestValidateCustomSchema(t *testing.T) {
+	schema := navigadoc.NewSchema("title-required", `{
+		"type": "object",
+		"required": ["title"]
+	}`)
+
+	errs, err := navigadoc.Validate([]byte(`{
+		"uuid": "8606660e-06d2-4ebe-bc3a-6c17cbfb6179",
+		"type": "x-im/article",
+		"created": "2017-02-22T08:12:40Z"
+	}`),
+		navigadoc.WithSchema(navigadoc.NavigaDocJSONSchema, schema))
+	must(t, err, "failed to validate")
+
+	if len(errs) != 1 || errs[0].Pointer != "/title" {
+		t.Errorf("expected a single error for /title, got %v", errs)
+	}
+
+	first, err := schema.Compile()
+	must(t, err, "failed to compile schema")
+
+	second, err := schema.Compile()
+	must(t, err, "failed to compile schema")
+
+	if first != second {
+		t.Error("expected the schema to be compiled once")
+	}
+
+	broken := navigadoc.NewSchema("broken", `{"type": 12}`)
+
+	_, err = navigadoc.Validate([]byte(`{}`), navigadoc.WithSchema(broken))
+	if err == nil {
+		t.Error("expected an error for a broken schema")
+	}
+}
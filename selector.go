@@ -0,0 +1,558 @@
+package navigadoc
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// Selector is a compiled block query that can be run against any
+// number of documents.
+//
+// A selector is a list of steps separated by "/" (children of the
+// previous step) or "//" (the previous step and all its descendants).
+// A step is a section name ("content", "meta" or "links"), a block type
+// such as "x-im/paragraph" or "*", followed by any number of
+// predicates:
+//
+//	[field]           the field is set
+//	[field=value]     equal
+//	[field!=value]    not equal
+//	[field^=value]    has prefix
+//	[field$=value]    has suffix
+//	[field*=value]    contains
+//	[field~=value]    matches the regular expression
+//
+// A section step selects the blocks in that list of the current
+// document or block, a type step selects blocks of that type in any of
+// the lists.
+//
+// Fields are the block fields using their JSON names, f.ex. "rel" or
+// "contentType", or "data.<key>" for values in Block.Data. Values can
+// be quoted with single or double quotes.
+//
+// Examples:
+//
+//	links[rel=author][type^=x-im/]/links[rel=affiliation]
+//	content//x-im/paragraph[data.format=html]
+//	content//x-im/content-part//x-im/image
+//	//*[uuid]
+//
+// Type names are expected to contain at most one "/", a type without
+// a "/" cannot be followed directly by a child step, use "*[type=name]"
+// instead.
+type Selector struct {
+	expr  string
+	steps []selectorStep
+}
+
+// SelectorMatch is a block found by a Selector.
+type SelectorMatch struct {
+	// Block points into the searched document.
+	Block *doc.Block
+	// Section is the list the block belongs to.
+	Section Section
+	// Path is a JSON pointer to the block, f.ex. "/content/1/links/0".
+	Path string
+}
+
+type selectorAxis int
+
+const (
+	axisChild selectorAxis = iota
+	axisDescendant
+)
+
+type selectorStep struct {
+	axis       selectorAxis
+	section    Section
+	blockType  string
+	predicates []selectorPredicate
+}
+
+type selectorOp int
+
+const (
+	opExists selectorOp = iota
+	opEquals
+	opNotEquals
+	opPrefix
+	opSuffix
+	opContains
+	opRegexp
+)
+
+type selectorPredicate struct {
+	field string
+	get   func(block *doc.Block) (string, bool)
+	op    selectorOp
+	value string
+	rx    *regexp.Regexp
+}
+
+// CompileSelector parses a selector expression.
+func CompileSelector(expr string) (*Selector, error) {
+	p := selectorParser{expr: expr}
+
+	steps, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Selector{expr: expr, steps: steps}, nil
+}
+
+// MustCompileSelector is like CompileSelector but panics if the
+// expression cannot be parsed.
+func MustCompileSelector(expr string) *Selector {
+	s, err := CompileSelector(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// SelectBlocks compiles the expression and returns copies of the
+// matching blocks.
+func SelectBlocks(document *doc.Document, expr string) ([]doc.Block, error) {
+	s, err := CompileSelector(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Select(document), nil
+}
+
+// String returns the source expression of the selector.
+func (s *Selector) String() string {
+	return s.expr
+}
+
+// Select returns copies of the blocks matching the selector in
+// document order.
+func (s *Selector) Select(document *doc.Document) []doc.Block {
+	var blocks []doc.Block
+
+	for _, m := range s.Find(document) {
+		blocks = append(blocks, *m.Block)
+	}
+
+	return blocks
+}
+
+// Find returns the blocks matching the selector together with their
+// positions in the document.
+func (s *Selector) Find(document *doc.Document) []SelectorMatch {
+	if document == nil {
+		return nil
+	}
+
+	context := []selectorNode{{document: document}}
+
+	for i := range s.steps {
+		step := &s.steps[i]
+		seen := make(map[string]bool)
+
+		var next []selectorNode
+
+		add := func(n selectorNode) {
+			if seen[n.path] || !step.match(n) {
+				return
+			}
+
+			seen[n.path] = true
+			next = append(next, n)
+		}
+
+		for _, n := range context {
+			switch step.axis {
+			case axisChild:
+				for _, c := range n.children() {
+					add(c)
+				}
+			case axisDescendant:
+				if n.block != nil {
+					add(n)
+				}
+
+				n.descendants(add)
+			}
+		}
+
+		context = next
+	}
+
+	matches := make([]SelectorMatch, 0, len(context))
+	for _, n := range context {
+		matches = append(matches, SelectorMatch{
+			Block:   n.block,
+			Section: n.section,
+			Path:    n.path,
+		})
+	}
+
+	return matches
+}
+
+type selectorNode struct {
+	document *doc.Document
+	block    *doc.Block
+	section  Section
+	path     string
+}
+
+func (n selectorNode) children() []selectorNode {
+	var content, meta, links []doc.Block
+
+	if n.block != nil {
+		content, meta, links = n.block.Content, n.block.Meta, n.block.Links
+	} else {
+		content, meta, links = n.document.Content, n.document.Meta, n.document.Links
+	}
+
+	var nodes []selectorNode
+
+	nodes = n.appendChildren(nodes, SectionContent, content)
+	nodes = n.appendChildren(nodes, SectionMeta, meta)
+	nodes = n.appendChildren(nodes, SectionLinks, links)
+
+	return nodes
+}
+
+func (n selectorNode) appendChildren(nodes []selectorNode, section Section, blocks []doc.Block) []selectorNode {
+	for i := range blocks {
+		nodes = append(nodes, selectorNode{
+			document: n.document,
+			block:    &blocks[i],
+			section:  section,
+			path:     n.path + "/" + string(section) + "/" + strconv.Itoa(i),
+		})
+	}
+
+	return nodes
+}
+
+func (n selectorNode) descendants(fn func(n selectorNode)) {
+	for _, c := range n.children() {
+		fn(c)
+		c.descendants(fn)
+	}
+}
+
+func (s *selectorStep) match(n selectorNode) bool {
+	if s.section != "" && s.section != n.section {
+		return false
+	}
+
+	if s.blockType != "" && s.blockType != "*" && s.blockType != n.block.Type {
+		return false
+	}
+
+	for i := range s.predicates {
+		if !s.predicates[i].match(n.block) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *selectorPredicate) match(block *doc.Block) bool {
+	v, ok := p.get(block)
+
+	switch p.op {
+	case opExists:
+		return ok
+	case opEquals:
+		return v == p.value
+	case opNotEquals:
+		return v != p.value
+	case opPrefix:
+		return strings.HasPrefix(v, p.value)
+	case opSuffix:
+		return strings.HasSuffix(v, p.value)
+	case opContains:
+		return strings.Contains(v, p.value)
+	case opRegexp:
+		return p.rx.MatchString(v)
+	}
+
+	return false
+}
+
+func stringField(get func(block *doc.Block) string) func(block *doc.Block) (string, bool) {
+	return func(block *doc.Block) (string, bool) {
+		v := get(block)
+		return v, v != ""
+	}
+}
+
+var selectorFields = map[string]func(block *doc.Block) (string, bool){
+	"id":          stringField(func(b *doc.Block) string { return b.ID }),
+	"uuid":        stringField(func(b *doc.Block) string { return b.UUID }),
+	"uri":         stringField(func(b *doc.Block) string { return b.URI }),
+	"url":         stringField(func(b *doc.Block) string { return b.URL }),
+	"type":        stringField(func(b *doc.Block) string { return b.Type }),
+	"title":       stringField(func(b *doc.Block) string { return b.Title }),
+	"rel":         stringField(func(b *doc.Block) string { return b.Rel }),
+	"name":        stringField(func(b *doc.Block) string { return b.Name }),
+	"value":       stringField(func(b *doc.Block) string { return b.Value }),
+	"contentType": stringField(func(b *doc.Block) string { return b.ContentType }),
+	"role":        stringField(func(b *doc.Block) string { return b.Role }),
+}
+
+type selectorParser struct {
+	expr string
+	pos  int
+}
+
+func (p *selectorParser) errorf(format string, args ...interface{}) error {
+	return InvalidArgumentError{
+		Msg: fmt.Sprintf("invalid selector %q at position %d: %s",
+			p.expr, p.pos, fmt.Sprintf(format, args...)),
+	}
+}
+
+func (p *selectorParser) eof() bool {
+	return p.pos >= len(p.expr)
+}
+
+func (p *selectorParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.expr[p.pos]
+}
+
+func (p *selectorParser) skipSpace() {
+	for !p.eof() && p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+func (p *selectorParser) parse() ([]selectorStep, error) {
+	var steps []selectorStep
+
+	p.skipSpace()
+
+	if p.eof() {
+		return nil, p.errorf("empty expression")
+	}
+
+	axis := p.parseAxis()
+
+	for {
+		step, err := p.parseStep()
+		if err != nil {
+			return nil, err
+		}
+
+		step.axis = axis
+		steps = append(steps, step)
+
+		p.skipSpace()
+
+		if p.eof() {
+			break
+		}
+
+		if p.peek() != '/' {
+			return nil, p.errorf("unexpected %q", p.peek())
+		}
+
+		axis = p.parseAxis()
+	}
+
+	return steps, nil
+}
+
+func (p *selectorParser) parseAxis() selectorAxis {
+	if strings.HasPrefix(p.expr[p.pos:], "//") {
+		p.pos += 2
+		return axisDescendant
+	}
+
+	if p.peek() == '/' {
+		p.pos++
+	}
+
+	return axisChild
+}
+
+func isSelectorNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		strings.IndexByte("-_.+:@", c) >= 0
+}
+
+func (p *selectorParser) readName() string {
+	start := p.pos
+	for !p.eof() && isSelectorNameChar(p.peek()) {
+		p.pos++
+	}
+
+	return p.expr[start:p.pos]
+}
+
+func (p *selectorParser) parseStep() (selectorStep, error) {
+	var step selectorStep
+
+	p.skipSpace()
+
+	switch {
+	case p.peek() == '*':
+		p.pos++
+		step.blockType = "*"
+	case isSelectorNameChar(p.peek()):
+		name := p.readName()
+
+		switch Section(name) {
+		case SectionContent, SectionMeta, SectionLinks:
+			step.section = Section(name)
+		default:
+			// Block types are on the form "prefix/name"
+			if p.peek() == '/' && p.pos+1 < len(p.expr) && isSelectorNameChar(p.expr[p.pos+1]) {
+				p.pos++
+				name += "/" + p.readName()
+			}
+
+			step.blockType = name
+		}
+	case p.peek() != '[':
+		return step, p.errorf("expected a section, type or predicate")
+	}
+
+	for p.peek() == '[' {
+		pred, err := p.parsePredicate()
+		if err != nil {
+			return step, err
+		}
+
+		step.predicates = append(step.predicates, pred)
+	}
+
+	return step, nil
+}
+
+var selectorOps = []struct {
+	token string
+	op    selectorOp
+}{
+	{"!=", opNotEquals},
+	{"^=", opPrefix},
+	{"$=", opSuffix},
+	{"*=", opContains},
+	{"~=", opRegexp},
+	{"=", opEquals},
+}
+
+func (p *selectorParser) parsePredicate() (selectorPredicate, error) {
+	var pred selectorPredicate
+
+	// Skip "["
+	p.pos++
+	p.skipSpace()
+
+	pred.field = p.readName()
+	if pred.field == "" {
+		return pred, p.errorf("expected a field name")
+	}
+
+	if strings.HasPrefix(pred.field, "data.") {
+		key := strings.TrimPrefix(pred.field, "data.")
+		pred.get = func(block *doc.Block) (string, bool) {
+			v, ok := block.Data[key]
+			return v, ok
+		}
+	} else {
+		get, ok := selectorFields[pred.field]
+		if !ok {
+			return pred, p.errorf("unknown field %q", pred.field)
+		}
+
+		pred.get = get
+	}
+
+	p.skipSpace()
+
+	if p.peek() == ']' {
+		p.pos++
+		pred.op = opExists
+
+		return pred, nil
+	}
+
+	found := false
+
+	for _, o := range selectorOps {
+		if strings.HasPrefix(p.expr[p.pos:], o.token) {
+			p.pos += len(o.token)
+			pred.op = o.op
+			found = true
+
+			break
+		}
+	}
+
+	if !found {
+		return pred, p.errorf("expected an operator")
+	}
+
+	p.skipSpace()
+
+	value, err := p.parseValue()
+	if err != nil {
+		return pred, err
+	}
+
+	pred.value = value
+
+	if pred.op == opRegexp {
+		pred.rx, err = regexp.Compile(value)
+		if err != nil {
+			return pred, p.errorf("invalid regular expression: %v", err)
+		}
+	}
+
+	p.skipSpace()
+
+	if p.peek() != ']' {
+		return pred, p.errorf("expected ]")
+	}
+
+	p.pos++
+
+	return pred, nil
+}
+
+func (p *selectorParser) parseValue() (string, error) {
+	quote := p.peek()
+
+	if quote == '"' || quote == '\'' {
+		p.pos++
+
+		end := strings.IndexByte(p.expr[p.pos:], quote)
+		if end < 0 {
+			return "", p.errorf("unterminated string")
+		}
+
+		value := p.expr[p.pos : p.pos+end]
+		p.pos += end + 1
+
+		return value, nil
+	}
+
+	end := strings.IndexByte(p.expr[p.pos:], ']')
+	if end < 0 {
+		return "", p.errorf("expected ]")
+	}
+
+	value := strings.TrimSpace(p.expr[p.pos : p.pos+end])
+	p.pos += end
+
+	return value, nil
+}
@@ -0,0 +1,88 @@
+package navigadoc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+)
+
+func TestSelector(t *testing.T) {
+	document := loadDocument(t, "./testdata/text.json")
+
+	testCases := []struct {
+		expr  string
+		paths []string
+	}{
+		{
+			expr:  "content//x-im/paragraph[data.format=html]",
+			paths: []string{"/content/3", "/content/4", "/content/5", "/content/7/content/0"},
+		},
+		{
+			expr:  "content//x-im/content-part//x-im/paragraph",
+			paths: []string{"/content/7/content/0", "/content/7/content/1"},
+		},
+		{
+			expr:  "links[rel=author][type^=x-im/]/links[rel=avatar]",
+			paths: []string{"/links/7/links/0"},
+		},
+		{
+			expr:  "content[type=x-im/image]/links[rel=self]/x-im/crop",
+			paths: []string{"/content/6/links/0/links/1", "/content/6/links/0/links/2"},
+		},
+		{
+			expr:  "//x-im/image[uri$=.jpeg]",
+			paths: []string{"/content/6/links/0", "/meta/2/links/0", "/links/7/links/0"},
+		},
+		{
+			expr:  "links[uri~='^[a-z-]+://[0-9]+$']",
+			paths: []string{"/links/9"},
+		},
+		{
+			expr:  "meta[data.score]",
+			paths: []string{"/meta/0"},
+		},
+		{
+			expr:  "links[rel != author][uuid][type*=channel]",
+			paths: []string{"/links/4", "/links/5"},
+		},
+		{
+			expr:  "content[type=x-im/header]",
+			paths: []string{"/content/0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		s, err := navigadoc.CompileSelector(tc.expr)
+		must(t, err, "could not compile selector "+tc.expr)
+
+		matches := s.Find(document)
+		if len(matches) != len(tc.paths) {
+			t.Errorf("%s: expected %d matches, got %d: %v", tc.expr, len(tc.paths), len(matches), matches)
+			continue
+		}
+
+		for i, m := range matches {
+			if m.Path != tc.paths[i] {
+				t.Errorf("%s: expected match %d at %s, was %s", tc.expr, i, tc.paths[i], m.Path)
+			}
+		}
+	}
+}
+
+func TestSelectorSyntaxErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"content/",
+		"links[rel=author",
+		"links[unknown=1]",
+		"links[rel]]",
+		"links[rel~=(]",
+		"links[rel='author]",
+	} {
+		_, err := navigadoc.CompileSelector(expr)
+		if !errors.Is(err, navigadoc.InvalidArgumentError{}) {
+			t.Errorf("%q: expected an invalid argument error, got %v", expr, err)
+		}
+	}
+}
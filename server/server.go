@@ -0,0 +1,342 @@
+// Package server implements the NavigaDoc gRPC service on top of the
+// navigadoc package functions.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/navigacontentlab/navigadoc/newsitem"
+	"github.com/navigacontentlab/navigadoc/newsml"
+	"github.com/navigacontentlab/navigadoc/ninjs"
+	"github.com/navigacontentlab/navigadoc/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements rpc.NavigaDocServer.
+type Server struct {
+	rpc.UnimplementedNavigaDocServer
+
+	validators map[rpc.Schema]*navigadoc.Validator
+	profiles   *navigadoc.ProfileRegistry
+}
+
+// NewServer creates a server, the schemas are compiled up front.
+// Documents are checked against the profiles in
+// navigadoc.DefaultProfiles.
+func NewServer() (*Server, error) {
+	s := Server{
+		validators: make(map[rpc.Schema]*navigadoc.Validator),
+		profiles:   navigadoc.DefaultProfiles,
+	}
+
+	for schema, source := range map[rpc.Schema]*navigadoc.Schema{
+		rpc.Schema_SCHEMA_NAVIGADOC: navigadoc.NavigaDocJSONSchema,
+		rpc.Schema_SCHEMA_CCA:       navigadoc.CCAJSONSchema,
+	} {
+		v, err := navigadoc.NewValidator(navigadoc.WithSchema(source))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s validator: %w", schema, err)
+		}
+
+		s.validators[schema] = v
+	}
+
+	return &s, nil
+}
+
+// Validate validates a JSON document against the requested schema, and
+// against the profile for its type if it's valid according to the
+// NavigaDoc schema.
+func (s *Server) Validate(ctx context.Context, req *rpc.ValidateRequest) (*rpc.ValidateResponse, error) {
+	v, ok := s.validators[req.Schema]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown schema %s", req.Schema)
+	}
+
+	errs, err := v.ValidateBytes(req.Document)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to validate document: %v", err)
+	}
+
+	if req.Schema == rpc.Schema_SCHEMA_NAVIGADOC && len(errs.Severe()) == 0 {
+		var document doc.Document
+
+		err = json.Unmarshal(req.Document, &document)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid document: %v", err)
+		}
+
+		var profileErrs navigadoc.ValidationErrors
+
+		err = s.profiles.Validate(&document)
+		if errors.As(err, &profileErrs) {
+			errs = append(errs, profileErrs...)
+		} else if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to validate profile: %v", err)
+		}
+	}
+
+	res := rpc.ValidateResponse{
+		Errors: make([]*rpc.ValidationError, len(errs)),
+	}
+
+	for i, e := range errs {
+		res.Errors[i] = &rpc.ValidationError{
+			Pointer:   e.Pointer,
+			Code:      string(e.Code),
+			Severity:  severity(e.Severity),
+			BlockType: e.BlockType,
+			BlockId:   e.BlockID,
+			Message:   e.Err.Error(),
+		}
+	}
+
+	return &res, nil
+}
+
+func severity(s navigadoc.Severity) rpc.Severity {
+	if s == navigadoc.SeverityWarning {
+		return rpc.Severity_SEVERITY_WARNING
+	}
+
+	return rpc.Severity_SEVERITY_ERROR
+}
+
+// Normalize returns the canonical form of a document and its hash.
+func (s *Server) Normalize(ctx context.Context, req *rpc.NormalizeRequest) (*rpc.NormalizeResponse, error) {
+	document, err := toDoc(req.Document, "document")
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := navigadoc.Canonicalize(document)
+
+	hash, err := navigadoc.Hash(canonical)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash document: %v", err)
+	}
+
+	res := rpc.NormalizeResponse{Hash: hash}
+
+	res.Document, err = fromDoc(canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Diff returns the changes needed to get from document a to b.
+func (s *Server) Diff(ctx context.Context, req *rpc.DiffRequest) (*rpc.DiffResponse, error) {
+	a, err := toDoc(req.A, "a")
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := toDoc(req.B, "b")
+	if err != nil {
+		return nil, err
+	}
+
+	changes := navigadoc.Diff(a, b)
+
+	res := rpc.DiffResponse{
+		Changes: make([]*rpc.Change, len(changes)),
+	}
+
+	for i, c := range changes {
+		change := rpc.Change{
+			Kind:    string(c.Kind),
+			Path:    c.Path,
+			OldPath: c.OldPath,
+			Field:   c.Field,
+		}
+
+		change.OldValue, err = jsonValue(c.OldValue)
+		if err != nil {
+			return nil, err
+		}
+
+		change.NewValue, err = jsonValue(c.NewValue)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Changes[i] = &change
+	}
+
+	return &res, nil
+}
+
+func jsonValue(v interface{}) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to marshal change value: %v", err)
+	}
+
+	return string(data), nil
+}
+
+// ConvertTo converts a document to the requested format.
+func (s *Server) ConvertTo(ctx context.Context, req *rpc.ConvertToRequest) (*rpc.ConvertToResponse, error) {
+	document, err := toDoc(req.Document, "document")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		res    rpc.ConvertToResponse
+		losses []ninjs.Loss
+	)
+
+	switch req.Format {
+	case rpc.Format_FORMAT_NAVIGADOC_JSON:
+		res.Data, err = json.Marshal(document)
+	case rpc.Format_FORMAT_NEWSITEM_XML:
+		res.Data, err = newsitem.ToNewsItemXML(document)
+	case rpc.Format_FORMAT_NEWSML_G2:
+		res.Data, err = newsml.FromDoc(document)
+	case rpc.Format_FORMAT_NINJS:
+		var item ninjs.Item
+
+		losses, err = item.FromDocDocument(document)
+		if err == nil {
+			res.Data, err = json.Marshal(&item)
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported format %s", req.Format)
+	}
+
+	if err != nil {
+		return nil, conversionError(err, req.Format)
+	}
+
+	res.Losses = fromLosses(losses)
+
+	return &res, nil
+}
+
+// ConvertFrom converts data in the requested format to a document.
+func (s *Server) ConvertFrom(ctx context.Context, req *rpc.ConvertFromRequest) (*rpc.ConvertFromResponse, error) {
+	var (
+		document *doc.Document
+		losses   []ninjs.Loss
+		err      error
+	)
+
+	switch req.Format {
+	case rpc.Format_FORMAT_NAVIGADOC_JSON:
+		document = &doc.Document{}
+
+		err = json.Unmarshal(req.Data, document)
+		if err != nil {
+			err = navigadoc.InvalidArgumentError{
+				Msg: fmt.Sprintf("invalid NavigaDoc JSON: %v", err),
+				Err: err,
+			}
+		}
+	case rpc.Format_FORMAT_NEWSITEM_XML:
+		document, err = newsitem.FromNewsItemXML(req.Data)
+	case rpc.Format_FORMAT_NEWSML_G2:
+		document, err = newsml.ToDoc(req.Data)
+	case rpc.Format_FORMAT_NINJS:
+		var item ninjs.Item
+
+		err = json.Unmarshal(req.Data, &item)
+		if err != nil {
+			err = navigadoc.InvalidArgumentError{
+				Msg: fmt.Sprintf("invalid ninjs JSON: %v", err),
+				Err: err,
+			}
+
+			break
+		}
+
+		document = &doc.Document{}
+		losses, err = item.ToDocDocument(document)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported format %s", req.Format)
+	}
+
+	if err != nil {
+		return nil, conversionError(err, req.Format)
+	}
+
+	res := rpc.ConvertFromResponse{
+		Losses: fromLosses(losses),
+	}
+
+	res.Document, err = fromDoc(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func fromLosses(losses []ninjs.Loss) []*rpc.Loss {
+	if len(losses) == 0 {
+		return nil
+	}
+
+	l := make([]*rpc.Loss, len(losses))
+
+	for i := range losses {
+		l[i] = &rpc.Loss{
+			Path:   losses[i].Path,
+			Reason: losses[i].Reason,
+		}
+	}
+
+	return l
+}
+
+// conversionError turns errors caused by the input into InvalidArgument
+// errors, and everything else into Internal errors.
+func conversionError(err error, format rpc.Format) error {
+	var malformed *navigadoc.MalformedDocumentError
+
+	if errors.Is(err, navigadoc.InvalidArgumentError{}) ||
+		errors.Is(err, navigadoc.RequiredArgumentError{}) ||
+		errors.As(err, &malformed) {
+		return status.Errorf(codes.InvalidArgument, "failed to convert %s: %v", format, err)
+	}
+
+	return status.Errorf(codes.Internal, "failed to convert %s: %v", format, err)
+}
+
+func toDoc(d *rpc.Document, name string) (*doc.Document, error) {
+	if d == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is required", name)
+	}
+
+	var document doc.Document
+
+	err := d.ToDocDocument(&document)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid %s: %v", name, err)
+	}
+
+	return &document, nil
+}
+
+func fromDoc(document *doc.Document) (*rpc.Document, error) {
+	var d rpc.Document
+
+	err := d.FromDocDocument(document)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert document: %v", err)
+	}
+
+	return &d, nil
+}
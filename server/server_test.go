@@ -0,0 +1,230 @@
+package server_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc/internal/testutil"
+	"github.com/navigacontentlab/navigadoc/rpc"
+	"github.com/navigacontentlab/navigadoc/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newClient starts a server on an in-memory listener and returns a
+// client connected to it. Both are stopped when the test ends.
+func newClient(t *testing.T) rpc.NavigaDocClient {
+	t.Helper()
+
+	srv, err := server.NewServer()
+	testutil.Must(t, err, "failed to create server")
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	rpc.RegisterNavigaDocServer(grpcServer, srv)
+
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	testutil.Must(t, err, "failed to dial server")
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return rpc.NewNavigaDocClient(conn)
+}
+
+func loadDocument(t *testing.T, path string) *rpc.Document {
+	t.Helper()
+
+	var d rpc.Document
+
+	err := d.FromDocDocument(testutil.LoadDocument(t, path))
+	testutil.Must(t, err, "could not convert doc")
+
+	return &d
+}
+
+func expectCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+
+	if status.Code(err) != code {
+		t.Fatalf("expected a %s error, got: %v", code, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	data, err := ioutil.ReadFile("../testdata/text.json")
+	testutil.Must(t, err, "could not open testfile")
+
+	res, err := client.Validate(ctx, &rpc.ValidateRequest{Document: data})
+	testutil.Must(t, err, "failed to validate document")
+
+	for _, e := range res.Errors {
+		if e.Severity == rpc.Severity_SEVERITY_ERROR {
+			t.Errorf("unexpected error at %q: %s", e.Pointer, e.Message)
+		}
+	}
+
+	res, err = client.Validate(ctx, &rpc.ValidateRequest{
+		Document: []byte(`{"uuid": "not-a-uuid", "type": "x-im/article", "created": "2022-03-01T12:00:00Z"}`),
+	})
+	testutil.Must(t, err, "failed to validate document")
+
+	if len(res.Errors) != 1 || res.Errors[0].Pointer != "/uuid" {
+		t.Fatalf("expected a single error for /uuid, got %v", res.Errors)
+	}
+
+	if !strings.HasPrefix(res.Errors[0].Code, "schema") {
+		t.Errorf("expected a schema error code, got %q", res.Errors[0].Code)
+	}
+
+	_, err = client.Validate(ctx, &rpc.ValidateRequest{Document: []byte("{")})
+	expectCode(t, err, codes.InvalidArgument)
+}
+
+func TestNormalize(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	document := loadDocument(t, "../testdata/text.json")
+	document.Uuid = strings.ToUpper(document.Uuid)
+
+	res, err := client.Normalize(ctx, &rpc.NormalizeRequest{Document: document})
+	testutil.Must(t, err, "failed to normalize document")
+
+	if res.Document.Uuid != strings.ToLower(document.Uuid) {
+		t.Errorf("expected the UUID to be lowercased, got %q", res.Document.Uuid)
+	}
+
+	if len(res.Hash) != 64 {
+		t.Errorf("expected a SHA-256 hash, got %q", res.Hash)
+	}
+
+	again, err := client.Normalize(ctx, &rpc.NormalizeRequest{Document: res.Document})
+	testutil.Must(t, err, "failed to normalize canonical document")
+
+	if again.Hash != res.Hash {
+		t.Errorf("expected normalizing to be idempotent, got hash %q, then %q", res.Hash, again.Hash)
+	}
+
+	_, err = client.Normalize(ctx, &rpc.NormalizeRequest{})
+	expectCode(t, err, codes.InvalidArgument)
+}
+
+func TestDiff(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	a := loadDocument(t, "../testdata/text.json")
+	b := loadDocument(t, "../testdata/text.json")
+	b.Title = "Changed"
+
+	res, err := client.Diff(ctx, &rpc.DiffRequest{A: a, B: b})
+	testutil.Must(t, err, "failed to diff documents")
+
+	if len(res.Changes) != 1 {
+		t.Fatalf("expected one change, got %v", res.Changes)
+	}
+
+	c := res.Changes[0]
+	if c.Kind != "modified" || c.Field != "title" || c.NewValue != `"Changed"` {
+		t.Errorf("unexpected change %v", c)
+	}
+
+	_, err = client.Diff(ctx, &rpc.DiffRequest{A: a})
+	expectCode(t, err, codes.InvalidArgument)
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	document := loadDocument(t, "../testdata/text.json")
+
+	for _, format := range []rpc.Format{
+		rpc.Format_FORMAT_NAVIGADOC_JSON,
+		rpc.Format_FORMAT_NEWSITEM_XML,
+		rpc.Format_FORMAT_NEWSML_G2,
+		rpc.Format_FORMAT_NINJS,
+	} {
+		t.Run(format.String(), func(t *testing.T) {
+			to, err := client.ConvertTo(ctx, &rpc.ConvertToRequest{
+				Document: document,
+				Format:   format,
+			})
+			testutil.Must(t, err, "failed to convert document")
+
+			if len(to.Data) == 0 {
+				t.Fatal("expected converted data")
+			}
+
+			from, err := client.ConvertFrom(ctx, &rpc.ConvertFromRequest{
+				Data:   to.Data,
+				Format: format,
+			})
+			testutil.Must(t, err, "failed to convert data")
+
+			if from.Document.Uuid != document.Uuid {
+				t.Errorf("expected UUID %q, got %q", document.Uuid, from.Document.Uuid)
+			}
+
+			if from.Document.Title != document.Title {
+				t.Errorf("expected title %q, got %q", document.Title, from.Document.Title)
+			}
+
+			if format == rpc.Format_FORMAT_NINJS && len(to.Losses) == 0 {
+				t.Error("expected ninjs conversion losses")
+			}
+		})
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	_, err := client.ConvertTo(ctx, &rpc.ConvertToRequest{
+		Document: loadDocument(t, "../testdata/text.json"),
+	})
+	expectCode(t, err, codes.InvalidArgument)
+
+	_, err = client.ConvertTo(ctx, &rpc.ConvertToRequest{
+		Document: &rpc.Document{},
+		Format:   rpc.Format_FORMAT_NEWSITEM_XML,
+	})
+	expectCode(t, err, codes.InvalidArgument)
+
+	for _, format := range []rpc.Format{
+		rpc.Format_FORMAT_NAVIGADOC_JSON,
+		rpc.Format_FORMAT_NEWSITEM_XML,
+		rpc.Format_FORMAT_NEWSML_G2,
+		rpc.Format_FORMAT_NINJS,
+	} {
+		_, err = client.ConvertFrom(ctx, &rpc.ConvertFromRequest{
+			Data:   []byte("<not"),
+			Format: format,
+		})
+		expectCode(t, err, codes.InvalidArgument)
+	}
+}
@@ -0,0 +1,158 @@
+// Code generated by protoc-gen-navigadoc. DO NOT EDIT.
+
+/** Timestamp is an RFC 3339 date and time, f.ex. "2022-03-01T12:00:00+01:00". */
+export type Timestamp = string;
+
+/**
+ * Document is a NavigaDoc document, the content together with its
+ * metadata and links.
+ */
+export interface Document {
+  /**
+   * UUID is a unique ID for the document, this can be a random v4
+   * UUID, or a URI-derived v5 UUID.
+   */
+  uuid: string;
+  /** Type is the content type of the document. */
+  type: string;
+  /**
+   * URI identifies the document (in a more human-readable way than
+   * the UUID)
+   */
+  uri?: string;
+  /** URL is the browseable location of the document (if any) */
+  url?: string;
+  /**
+   * Title is the title of the document, often used as the headline
+   * when the document is displayed.
+   */
+  title?: string;
+  /**
+   * Path is the path on which the document can be exposed when
+   * consumed through a website.
+   */
+  path?: string;
+  /**
+   * Products is a list of products that the document should be used
+   * in.
+   *
+   * @deprecated
+   */
+  products?: string[];
+  /** Created is the initial creation time of the document. */
+  created: Timestamp;
+  /**
+   * Modified is the modified time as is should be presented to end
+   * users, the actual modified timestamp is recorded in the document
+   * commit. There is probably no reason not to update this timestamp
+   * when doing manual edits.  Automated tools and systems should
+   * probably leave it alone tho.
+   */
+  modified?: Timestamp;
+  /**
+   * Published is the published timestamp as it should be presented to
+   * end users. The actual published timestamp is recorded in the
+   * document commits in the "usable" branch. This shouldn't be
+   * touched after the initial publishing of the document.
+   */
+  published?: Timestamp;
+  /**
+   * Content is the content of the documen, this is essentially what
+   * gets rendered on the page when you view a document.
+   */
+  content?: Block[];
+  /**
+   * Meta is the metadata for a document, this could be stuff like
+   * open graph tags and content profile information.
+   */
+  meta?: Block[];
+  /**
+   * Links are links to other resources and entities. This could be
+   * links to categories and subject for the document, or authors.
+   */
+  links?: Block[];
+  /**
+   * Properties are header-like properties for a document. This is
+   * mainly used as a bucket for document-level stuff that needs to be
+   * preserved when converting to and from other document formats.
+   */
+  properties?: Property[];
+  /**
+   * Source is the name of the source of the document, usually the
+   * name of the application that generated it (or allowed a user to
+   * generate it).
+   */
+  source?: string;
+  /**
+   * Language is the language used in the document as an IETF language
+   * tag. F.ex. "en", "en-UK", "es", or "sv-SE".
+   */
+  language?: string;
+  /**
+   * A free form field detailing the status for the document, for example:
+   * "draft" or "withheld".
+   */
+  status?: string;
+  unpublished?: Timestamp;
+  provider?: string;
+}
+
+/** Property is a key-value pair */
+export interface Property {
+  name: string;
+  value?: string;
+  parameters?: { [key: string]: string };
+}
+
+/**
+ * Block is the building block for data embedded in documents. It is
+ * used for both content, links and metadata. Blocks have can be
+ * nested, but that's nothing to strive for, keep it simple.
+ */
+export interface Block {
+  /** ID is the block ID */
+  id?: string;
+  /** UUID is used to reference another Document in a block. */
+  uuid?: string;
+  /** URI is used to reference another entity in a document. */
+  uri?: string;
+  /** URL is a browseable URL for the the block. */
+  url?: string;
+  /** Type is the type of the block */
+  type?: string;
+  /**
+   * Title is the title/headline of the block, typically used in the
+   * presentation of the block.
+   */
+  title?: string;
+  /** Data contains block data */
+  data?: { [key: string]: string };
+  /**
+   * Relationship describes the relationship to the document/parent
+   * entity
+   */
+  rel?: string;
+  /**
+   * Name is a name for the block. An alternative to "rel" when
+   * relationship is a term that doesn't fit.
+   */
+  name?: string;
+  /**
+   * Value is a value for the block. Useful when we want to store a
+   * primitive value.
+   */
+  value?: string;
+  /**
+   * ContentType is used to describe the content type of the
+   * block/linked entity if it differs from the type of the block.
+   */
+  contentType?: string;
+  /** Links are used to link to other resources and documents. */
+  links?: Block[];
+  /** Content is used to embed content blocks. */
+  content?: Block[];
+  /** Meta is used to embed metadata */
+  meta?: Block[];
+  /** Role is used for */
+  role?: string;
+}
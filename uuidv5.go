@@ -0,0 +1,130 @@
+package navigadoc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// URINamespace is the namespace used for URI-derived v5 UUIDs, it's the
+// RFC 4122 URL namespace.
+var URINamespace = uuid.NameSpaceURL
+
+// URIToUUID returns the v5 UUID for a URI in URINamespace, f.ex.
+// "im://image/xs9TSZ7gBFDuJ-y4oTt17J9oOn0.jpg" gives
+// "6d587e06-57ed-522f-a06b-4f3dc032ff9b".
+func URIToUUID(uri string) string {
+	return URIToUUIDInNamespace(URINamespace, uri)
+}
+
+// URIToUUIDInNamespace returns the v5 UUID for a URI in the given
+// namespace.
+func URIToUUIDInNamespace(namespace uuid.UUID, uri string) string {
+	return uuid.NewSHA1(namespace, []byte(uri)).String()
+}
+
+// URIUUIDChecker checks and fills URI-derived UUIDs. The zero value
+// uses URINamespace.
+type URIUUIDChecker struct {
+	// Namespace for the v5 UUIDs, uuid.Nil means URINamespace.
+	Namespace uuid.UUID
+	// Strict makes all UUIDs paired with a URI subject to the check,
+	// otherwise only v5 UUIDs are checked as other versions can't have
+	// been derived from the URI.
+	Strict bool
+}
+
+func (c URIUUIDChecker) uuidFor(uri string) string {
+	ns := c.Namespace
+	if ns == uuid.Nil {
+		ns = URINamespace
+	}
+
+	return URIToUUIDInNamespace(ns, uri)
+}
+
+func (c URIUUIDChecker) check(kind, uri, theUUID string) error {
+	if uri == "" || theUUID == "" {
+		return nil
+	}
+
+	parsed, err := uuid.Parse(theUUID)
+	if err != nil {
+		return InvalidArgumentError{
+			Msg: fmt.Sprintf("uuid error %s[%s]: invalid uuid: %v", kind, theUUID, err),
+			Err: err,
+		}
+	}
+
+	if !c.Strict && parsed.Version() != 5 {
+		return nil
+	}
+
+	expected := c.uuidFor(uri)
+	if !strings.EqualFold(expected, theUUID) {
+		return InvalidArgumentError{
+			Msg: fmt.Sprintf("uuid error %s[%s]: expected %s for uri %s", kind, theUUID, expected, uri),
+		}
+	}
+
+	return nil
+}
+
+// CheckBlock is a BlockVisitor that verifies that blocks with both a
+// URI and a UUID have a UUID derived from the URI.
+func (c URIUUIDChecker) CheckBlock(block doc.Block, args ...interface{}) (doc.Block, error) {
+	return block, c.check(block.Type, block.URI, block.UUID)
+}
+
+// FillBlock is a BlockVisitor that sets the UUID of blocks that have a
+// URI but no UUID.
+func (c URIUUIDChecker) FillBlock(block doc.Block, args ...interface{}) (doc.Block, error) {
+	if block.URI != "" && block.UUID == "" {
+		block.UUID = c.uuidFor(block.URI)
+	}
+
+	return block, nil
+}
+
+// CheckDocument verifies the URI and UUID of the document and all its
+// blocks.
+func (c URIUUIDChecker) CheckDocument(document *doc.Document) error {
+	if document == nil {
+		return nil
+	}
+
+	err := c.check(document.Type, document.URI, document.UUID)
+	if err != nil {
+		return err
+	}
+
+	return WalkDocument(document, nil, c.CheckBlock)
+}
+
+// FillDocument sets missing UUIDs on the document and its blocks from
+// their URIs.
+func (c URIUUIDChecker) FillDocument(document *doc.Document) error {
+	if document == nil {
+		return nil
+	}
+
+	if document.URI != "" && document.UUID == "" {
+		document.UUID = c.uuidFor(document.URI)
+	}
+
+	return WalkDocument(document, nil, c.FillBlock)
+}
+
+// CheckURIUUIDs is a BlockVisitor that verifies URI-derived UUIDs in
+// URINamespace, see URIUUIDChecker.
+func CheckURIUUIDs(block doc.Block, args ...interface{}) (doc.Block, error) {
+	return URIUUIDChecker{}.CheckBlock(block, args...)
+}
+
+// FillUUIDsFromURIs is a BlockVisitor that sets missing UUIDs from URIs
+// in URINamespace.
+func FillUUIDsFromURIs(block doc.Block, args ...interface{}) (doc.Block, error) {
+	return URIUUIDChecker{}.FillBlock(block, args...)
+}
@@ -0,0 +1,111 @@
+package navigadoc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+func TestURIToUUID(t *testing.T) {
+	got := navigadoc.URIToUUID("im://image/xs9TSZ7gBFDuJ-y4oTt17J9oOn0.jpg")
+	if got != "6d587e06-57ed-522f-a06b-4f3dc032ff9b" {
+		t.Errorf("unexpected uuid %s", got)
+	}
+
+	other := navigadoc.URIToUUIDInNamespace(uuid.NameSpaceDNS, "im://image/xs9TSZ7gBFDuJ-y4oTt17J9oOn0.jpg")
+	if other == got {
+		t.Error("expected namespace to change the uuid")
+	}
+}
+
+func TestCheckURIUUIDs(t *testing.T) {
+	tests := []TestData{
+		{json: "./testdata/ampersand-image.json"},
+		{json: "./testdata/ampersand-article.json"},
+		{json: "./testdata/assignment.json"},
+		{json: "./testdata/image.json"},
+		{json: "./testdata/image2.json"},
+		{json: "./testdata/pdf.json"},
+		{json: "./testdata/text.json"},
+		{json: "./testdata/uuids-uppercase.json"},
+	}
+
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.json, func(t *testing.T) {
+			document := loadDocument(t, test.json)
+
+			err := navigadoc.URIUUIDChecker{}.CheckDocument(document)
+			if !test.expectError && err != nil {
+				t.Error(err)
+			} else if test.expectError && err == nil {
+				t.Error("error was expected")
+			}
+
+			if test.expectError {
+				return
+			}
+
+			err = navigadoc.WalkDocument(document, nil, navigadoc.CheckURIUUIDs)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	// A v1 UUID can't be derived from the URI and is only checked in
+	// strict mode.
+	avatar := &doc.Document{Links: []doc.Block{{
+		Rel: "avatar", UUID: "9c188460-c500-11e5-9912-ba0be0483c18", URI: "im://image/janedoe.jpeg",
+	}}}
+
+	must(t, navigadoc.URIUUIDChecker{}.CheckDocument(avatar), "non-strict check")
+
+	strict := navigadoc.URIUUIDChecker{Strict: true}
+	if err := strict.CheckDocument(avatar); err == nil {
+		t.Error("expected strict check to fail")
+	}
+
+	document := loadDocument(t, "./testdata/ampersand-image.json")
+	document.UUID = "6fecb214-3872-5122-9589-86ad60ff0886"
+
+	err := navigadoc.URIUUIDChecker{}.CheckDocument(document)
+	if !errors.Is(err, navigadoc.InvalidArgumentError{}) {
+		t.Errorf("expected InvalidArgumentError, got %v", err)
+	}
+}
+
+func TestFillUUIDsFromURIs(t *testing.T) {
+	document := &doc.Document{
+		URI: "im://image/xs9TSZ7gBFDuJ-y4oTt17J9oOn0.jpg",
+		Links: []doc.Block{
+			{Rel: "image", URI: "im://image/ICKdkOvDXgHY0jJELhtZreunxQ8.jpg"},
+			{Rel: "author", UUID: "77fe8f0c-4aaf-4b5d-8369-08dac4a67878", URI: "im://author/jane"},
+		},
+	}
+
+	err := navigadoc.URIUUIDChecker{}.FillDocument(document)
+	must(t, err, "failed to fill uuids")
+
+	if document.UUID != "6d587e06-57ed-522f-a06b-4f3dc032ff9b" {
+		t.Errorf("unexpected document uuid %s", document.UUID)
+	}
+
+	if document.Links[0].UUID != "b6142d33-e191-59c6-9c45-06772b3e922b" {
+		t.Errorf("unexpected link uuid %s", document.Links[0].UUID)
+	}
+
+	if document.Links[1].UUID != "77fe8f0c-4aaf-4b5d-8369-08dac4a67878" {
+		t.Error("expected existing uuid to be kept")
+	}
+
+	links := []doc.Block{{URI: "im://image/ICKdkOvDXgHY0jJELhtZreunxQ8.jpg"}}
+	must(t, navigadoc.WalkBlocks(links, nil, navigadoc.FillUUIDsFromURIs), "failed to walk")
+
+	if links[0].UUID != "b6142d33-e191-59c6-9c45-06772b3e922b" {
+		t.Errorf("unexpected block uuid %s", links[0].UUID)
+	}
+}
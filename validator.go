@@ -0,0 +1,270 @@
+package navigadoc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"runtime"
+	"sync"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Validator validates documents against a set of compiled schemas. It's
+// safe for concurrent use.
+type Validator struct {
+	schemas []*Schema
+}
+
+// NewValidator creates a validator for the NavigaDoc schema, or the
+// schemas given by WithSchema. The schemas are compiled up front.
+func NewValidator(opts ...ValidationOption) (*Validator, error) {
+	var o validationOptions
+
+	for i := range opts {
+		opts[i](&o)
+	}
+
+	if len(o.schemas) == 0 {
+		o.schemas = []*Schema{NavigaDocJSONSchema}
+	}
+
+	for _, s := range o.schemas {
+		_, err := s.Compile()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Validator{schemas: o.schemas}, nil
+}
+
+// ValidateBytes validates a JSON document. The returned ValidationErrors
+// contain the schema issues, a non-nil error indicates an error with the
+// validator or the input.
+func (v *Validator) ValidateBytes(data []byte) (ValidationErrors, error) {
+	if !json.Valid(data) {
+		var v interface{}
+
+		err := json.Unmarshal(data, &v)
+
+		return nil, InvalidArgumentError{
+			Msg: fmt.Sprintf("invalid JSON document: %v", err),
+			Err: err,
+		}
+	}
+
+	return v.validate(gojsonschema.NewBytesLoader(data), func() interface{} {
+		var root interface{}
+
+		_ = json.Unmarshal(data, &root)
+
+		return root
+	})
+}
+
+// ValidateReader validates a JSON document read from r.
+func (v *Validator) ValidateReader(r io.Reader) (ValidationErrors, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document: %w", err)
+	}
+
+	return v.ValidateBytes(data)
+}
+
+// ValidateDocument validates the JSON representation of a document.
+func (v *Validator) ValidateDocument(document *doc.Document) (ValidationErrors, error) {
+	if document == nil {
+		document = &doc.Document{}
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	return v.ValidateBytes(data)
+}
+
+// ValidateValue validates a decoded JSON value.
+func (v *Validator) ValidateValue(root interface{}) (ValidationErrors, error) {
+	return v.validate(gojsonschema.NewGoLoader(root), func() interface{} {
+		return root
+	})
+}
+
+// validate runs the document through all schemas, root is only called
+// if there are errors that need to be attributed to blocks.
+func (v *Validator) validate(document gojsonschema.JSONLoader, root func() interface{}) (ValidationErrors, error) {
+	var (
+		errs    ValidationErrors
+		decoded interface{}
+	)
+
+	for _, s := range v.schemas {
+		result, err := s.ValidateLoader(document)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Valid() {
+			continue
+		}
+
+		if decoded == nil {
+			decoded = root()
+		}
+
+		errs = append(errs, schemaValidationErrors(result.Errors(), decoded)...)
+	}
+
+	return errs, nil
+}
+
+// BatchResult is the outcome of validating one document in a batch.
+type BatchResult struct {
+	// Index is the position of the document in the batch or stream.
+	Index  int
+	Errors ValidationErrors
+	// Err is set if the document couldn't be validated.
+	Err error
+}
+
+// ValidateBatch validates the documents using a pool of workers and
+// returns the results in input order. A workers count of zero or less
+// uses one worker per CPU.
+func (v *Validator) ValidateBatch(ctx context.Context, documents [][]byte, workers int) ([]BatchResult, error) {
+	jobs := make(chan batchJob)
+	results := make([]BatchResult, len(documents))
+
+	go func() {
+		defer close(jobs)
+
+		for i := range documents {
+			select {
+			case jobs <- batchJob{index: i, data: documents[i]}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for res := range v.runWorkers(ctx, jobs, workers) {
+		results[res.Index] = res
+	}
+
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// ValidateStream validates a stream of concatenated or newline
+// delimited JSON documents using a pool of workers. Results are sent in
+// completion order, use BatchResult.Index to correlate them with the
+// input. The channel is closed when the stream has been consumed or the
+// context is cancelled, and must be drained by the caller. A stream that
+// can't be decoded yields a final result with Err set.
+func (v *Validator) ValidateStream(ctx context.Context, r io.Reader, workers int) <-chan BatchResult {
+	jobs := make(chan batchJob)
+	decodeErr := make(chan BatchResult, 1)
+
+	go func() {
+		defer close(jobs)
+		defer close(decodeErr)
+
+		dec := json.NewDecoder(r)
+
+		for i := 0; ; i++ {
+			var raw json.RawMessage
+
+			err := dec.Decode(&raw)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				decodeErr <- BatchResult{
+					Index: i,
+					Err: InvalidArgumentError{
+						Msg: fmt.Sprintf("invalid JSON document: %v", err),
+						Err: err,
+					},
+				}
+
+				return
+			}
+
+			select {
+			case jobs <- batchJob{index: i, data: raw}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	out := make(chan BatchResult)
+
+	go func() {
+		defer close(out)
+
+		for res := range v.runWorkers(ctx, jobs, workers) {
+			out <- res
+		}
+
+		for res := range decodeErr {
+			out <- res
+		}
+	}()
+
+	return out
+}
+
+type batchJob struct {
+	index int
+	data  []byte
+}
+
+func (v *Validator) runWorkers(ctx context.Context, jobs <-chan batchJob, workers int) <-chan BatchResult {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make(chan BatchResult)
+
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			for job := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				errs, err := v.ValidateBytes(job.data)
+				results <- BatchResult{
+					Index:  job.index,
+					Errors: errs,
+					Err:    err,
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
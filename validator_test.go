@@ -0,0 +1,232 @@
+package navigadoc_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+func TestValidatorInputs(t *testing.T) {
+	v, err := navigadoc.NewValidator()
+	must(t, err, "failed to create validator")
+
+	data, err := ioutil.ReadFile("./testdata/text.json")
+	must(t, err, "could not open testfile")
+
+	errs, err := v.ValidateBytes(data)
+	must(t, err, "failed to validate bytes")
+
+	if len(errs) != 0 {
+		t.Errorf("expected valid bytes, got %v", errs)
+	}
+
+	errs, err = v.ValidateReader(bytes.NewReader(data))
+	must(t, err, "failed to validate reader")
+
+	if len(errs) != 0 {
+		t.Errorf("expected valid reader, got %v", errs)
+	}
+
+	document := loadDocument(t, "./testdata/text.json")
+	document.Links[0].UUID = "not-a-uuid"
+
+	errs, err = v.ValidateDocument(document)
+	must(t, err, "failed to validate document")
+
+	if len(errs) != 1 || errs[0].Pointer != "/links/0/uuid" {
+		t.Errorf("expected a single uuid error, got %v", errs)
+	}
+
+	_, err = v.ValidateBytes([]byte(`{"uuid":`))
+	if !errors.Is(err, navigadoc.InvalidArgumentError{}) {
+		t.Errorf("expected InvalidArgumentError for invalid JSON, got %v", err)
+	}
+}
+
+func TestValidatorConcurrent(t *testing.T) {
+	v, err := navigadoc.NewValidator(navigadoc.WithSchema(navigadoc.CCAJSONSchema))
+	must(t, err, "failed to create validator")
+
+	data, err := ioutil.ReadFile("./examples/cca-example.json")
+	must(t, err, "could not open testfile")
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs, err := v.ValidateBytes(data)
+			if err != nil || len(errs) != 0 {
+				t.Errorf("expected valid document, got %v %v", errs, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestValidatorBatch(t *testing.T) {
+	v, err := navigadoc.NewValidator()
+	must(t, err, "failed to create validator")
+
+	valid, err := ioutil.ReadFile("./testdata/image.json")
+	must(t, err, "could not open testfile")
+
+	invalid := []byte(`{"type": "x-im/article"}`)
+	documents := [][]byte{valid, invalid, valid, []byte(`nope`)}
+
+	results, err := v.ValidateBatch(context.Background(), documents, 2)
+	must(t, err, "failed to validate batch")
+
+	for i, res := range results {
+		if res.Index != i {
+			t.Errorf("expected result %d to have index %d, was %d", i, i, res.Index)
+		}
+	}
+
+	if len(results[0].Errors) != 0 || len(results[2].Errors) != 0 {
+		t.Error("expected valid documents to pass")
+	}
+
+	if len(results[1].Errors) == 0 {
+		t.Error("expected schema errors for the invalid document")
+	}
+
+	if !errors.Is(results[3].Err, navigadoc.InvalidArgumentError{}) {
+		t.Errorf("expected InvalidArgumentError for malformed JSON, got %v", results[3].Err)
+	}
+}
+
+func TestValidatorStream(t *testing.T) {
+	v, err := navigadoc.NewValidator()
+	must(t, err, "failed to create validator")
+
+	var stream bytes.Buffer
+
+	for _, f := range []string{"./testdata/text.json", "./testdata/image.json", "./testdata/pdf.json"} {
+		data, err := ioutil.ReadFile(f)
+		must(t, err, "could not open testfile")
+
+		stream.Write(data)
+		stream.WriteString("\n")
+	}
+
+	stream.WriteString(`{"type": "x-im/article"}` + "\n{broken")
+
+	results := make(map[int]navigadoc.BatchResult)
+	for res := range v.ValidateStream(context.Background(), &stream, 2) {
+		results[res.Index] = res
+	}
+
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(results))
+	}
+
+	for i := 0; i < 3; i++ {
+		if results[i].Err != nil || len(results[i].Errors) != 0 {
+			t.Errorf("expected document %d to be valid, got %v %v", i, results[i].Errors, results[i].Err)
+		}
+	}
+
+	if len(results[3].Errors) == 0 {
+		t.Error("expected schema errors for document 3")
+	}
+
+	if !errors.Is(results[4].Err, navigadoc.InvalidArgumentError{}) {
+		t.Errorf("expected InvalidArgumentError for document 4, got %v", results[4].Err)
+	}
+}
+
+func BenchmarkValidateUncached(b *testing.B) {
+	data, err := ioutil.ReadFile("./testdata/text.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	document := string(data)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// This is how ValidateNavigadocJSON used to validate, with the
+		// schema being parsed for every document.
+		_, err := gojsonschema.Validate(
+			gojsonschema.NewStringLoader(navigadoc.NavigaDocSchema),
+			gojsonschema.NewStringLoader(document),
+		)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateNavigadocJSON(b *testing.B) {
+	data, err := ioutil.ReadFile("./testdata/text.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	document := string(data)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := navigadoc.ValidateNavigadocJSON(document)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidator(b *testing.B) {
+	data, err := ioutil.ReadFile("./testdata/text.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	v, err := navigadoc.NewValidator()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := v.ValidateBytes(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidatorParallel(b *testing.B) {
+	data, err := ioutil.ReadFile("./testdata/text.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	v, err := navigadoc.NewValidator()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, err := v.ValidateBytes(data)
+			if err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
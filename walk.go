@@ -0,0 +1,206 @@
+package navigadoc
+
+import (
+	"strconv"
+
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+// Section identifies which block list of a document or block a block
+// belongs to.
+type Section string
+
+const (
+	SectionContent Section = "content"
+	SectionMeta    Section = "meta"
+	SectionLinks   Section = "links"
+)
+
+// WalkAction tells Walk how to proceed after a block has been visited.
+type WalkAction int
+
+const (
+	// WalkContinue continues with the children of the block and then
+	// its siblings.
+	WalkContinue WalkAction = iota
+	// WalkSkipChildren continues with the siblings of the block
+	// without visiting its children.
+	WalkSkipChildren
+	// WalkStop ends the walk, changes made so far are kept.
+	WalkStop
+	// WalkDelete removes the block from its parent list, its children
+	// are not visited.
+	WalkDelete
+)
+
+// Cursor describes the position of the visited block in the document.
+type Cursor struct {
+	// Document is the document being walked.
+	Document *doc.Document
+	// Section is the list the block belongs to.
+	Section Section
+	// Index is the position of the block in its list in the input
+	// document.
+	Index int
+	// Path is a JSON pointer to the block in the input document,
+	// f.ex. "/content/1/links/0".
+	Path string
+	// Depth is 0 for blocks directly on the document.
+	Depth int
+	// Parents is the chain of ancestor blocks, closest ancestor last.
+	Parents []*doc.Block
+
+	before []doc.Block
+	after  []doc.Block
+}
+
+// Parent returns the closest ancestor block, or nil when the block is
+// placed directly on the document.
+func (c *Cursor) Parent() *doc.Block {
+	if len(c.Parents) == 0 {
+		return nil
+	}
+
+	return c.Parents[len(c.Parents)-1]
+}
+
+// InsertBefore adds siblings before the visited block. Inserted blocks
+// are not visited.
+func (c *Cursor) InsertBefore(blocks ...doc.Block) {
+	c.before = append(c.before, blocks...)
+}
+
+// InsertAfter adds siblings after the visited block. Inserted blocks
+// are not visited.
+func (c *Cursor) InsertAfter(blocks ...doc.Block) {
+	c.after = append(c.after, blocks...)
+}
+
+// PathVisitor is called for each block by Walk. The block can be
+// modified in place.
+type PathVisitor func(block *doc.Block, cursor *Cursor) (WalkAction, error)
+
+// Walk visits every block in the document depth first, in the order
+// content, meta and links, and applies the actions returned by the
+// visitor.
+func Walk(document *doc.Document, visitor PathVisitor) error {
+	if document == nil || visitor == nil {
+		return nil
+	}
+
+	w := walker{document: document, visitor: visitor}
+
+	var err error
+
+	document.Content, err = w.walkList(SectionContent, document.Content, "", nil)
+	if err != nil || w.stopped {
+		return err
+	}
+
+	document.Meta, err = w.walkList(SectionMeta, document.Meta, "", nil)
+	if err != nil || w.stopped {
+		return err
+	}
+
+	document.Links, err = w.walkList(SectionLinks, document.Links, "", nil)
+
+	return err
+}
+
+type walker struct {
+	document *doc.Document
+	visitor  PathVisitor
+	stopped  bool
+}
+
+func (w *walker) walkList(section Section, blocks []doc.Block, parentPath string, parents []*doc.Block) ([]doc.Block, error) {
+	if len(blocks) == 0 {
+		return blocks, nil
+	}
+
+	listPath := parentPath + "/" + string(section)
+	changed := false
+	result := make([]doc.Block, 0, len(blocks))
+
+	for i := range blocks {
+		if w.stopped {
+			result = append(result, blocks[i:]...)
+			break
+		}
+
+		block := blocks[i]
+		cursor := Cursor{
+			Document: w.document,
+			Section:  section,
+			Index:    i,
+			Path:     listPath + "/" + strconv.Itoa(i),
+			Depth:    len(parents),
+			Parents:  parents,
+		}
+
+		action, err := w.visitor(&block, &cursor)
+		if err != nil {
+			return blocks, err
+		}
+
+		if len(cursor.before) > 0 || len(cursor.after) > 0 {
+			changed = true
+		}
+
+		result = append(result, cursor.before...)
+
+		switch action {
+		case WalkDelete:
+			changed = true
+			result = append(result, cursor.after...)
+
+			continue
+		case WalkStop:
+			w.stopped = true
+		case WalkContinue:
+			err = w.walkChildren(&block, cursor.Path, parents)
+			if err != nil {
+				return blocks, err
+			}
+		case WalkSkipChildren:
+		}
+
+		result = append(result, block)
+		result = append(result, cursor.after...)
+	}
+
+	if !changed {
+		copy(blocks, result)
+		return blocks, nil
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return result, nil
+}
+
+func (w *walker) walkChildren(block *doc.Block, path string, parents []*doc.Block) error {
+	// Copy the ancestor chain so that sibling subtrees don't share
+	// a backing array.
+	chain := make([]*doc.Block, len(parents), len(parents)+1)
+	copy(chain, parents)
+	chain = append(chain, block)
+
+	var err error
+
+	block.Content, err = w.walkList(SectionContent, block.Content, path, chain)
+	if err != nil || w.stopped {
+		return err
+	}
+
+	block.Meta, err = w.walkList(SectionMeta, block.Meta, path, chain)
+	if err != nil || w.stopped {
+		return err
+	}
+
+	block.Links, err = w.walkList(SectionLinks, block.Links, path, chain)
+
+	return err
+}
@@ -0,0 +1,127 @@
+package navigadoc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/navigacontentlab/navigadoc"
+	"github.com/navigacontentlab/navigadoc/doc"
+)
+
+func TestWalkPaths(t *testing.T) {
+	document := loadDocument(t, "./testdata/objecttexttocontent.json")
+
+	var paths []string
+	var parentTypes []string
+
+	err := navigadoc.Walk(document, func(block *doc.Block, cursor *navigadoc.Cursor) (navigadoc.WalkAction, error) {
+		paths = append(paths, cursor.Path)
+
+		parent := cursor.Parent()
+		if parent != nil {
+			parentTypes = append(parentTypes, parent.Type)
+		}
+
+		if block.Type == "x-im/paragraph" && cursor.Depth != 1 {
+			t.Errorf("expected paragraph at depth 1, was %d", cursor.Depth)
+		}
+
+		return navigadoc.WalkContinue, nil
+	})
+	must(t, err, "walk failed")
+
+	expected := []string{
+		"/content/0",
+		"/content/1",
+		"/content/1/content/0",
+		"/content/1/content/1",
+		"/content/1/links/0",
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected %d visited blocks, was %d: %v", len(expected), len(paths), paths)
+	}
+
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("expected path %s, was %s", expected[i], paths[i])
+		}
+	}
+
+	for _, p := range parentTypes {
+		if p != "x-im/content-part" {
+			t.Errorf("expected parent x-im/content-part, was %s", p)
+		}
+	}
+}
+
+func TestWalkActions(t *testing.T) {
+	document := &doc.Document{
+		Content: []doc.Block{
+			{Type: "a", Content: []doc.Block{{Type: "a-child"}}},
+			{Type: "b", Content: []doc.Block{{Type: "b-child"}}},
+			{Type: "delete", Content: []doc.Block{{Type: "delete-child"}}},
+			{Type: "c"},
+		},
+		Links: []doc.Block{{Type: "link"}},
+	}
+
+	var visited []string
+
+	err := navigadoc.Walk(document, func(block *doc.Block, cursor *navigadoc.Cursor) (navigadoc.WalkAction, error) {
+		visited = append(visited, block.Type)
+
+		switch block.Type {
+		case "a":
+			block.Title = "changed"
+			cursor.InsertBefore(doc.Block{Type: "inserted-before"})
+		case "b":
+			cursor.InsertAfter(doc.Block{Type: "inserted-after"})
+			return navigadoc.WalkSkipChildren, nil
+		case "delete":
+			return navigadoc.WalkDelete, nil
+		case "c":
+			return navigadoc.WalkStop, nil
+		}
+
+		return navigadoc.WalkContinue, nil
+	})
+	must(t, err, "walk failed")
+
+	expectedVisits := []string{"a", "a-child", "b", "delete", "c"}
+	if len(visited) != len(expectedVisits) {
+		t.Fatalf("expected visits %v, was %v", expectedVisits, visited)
+	}
+
+	for i := range expectedVisits {
+		if visited[i] != expectedVisits[i] {
+			t.Errorf("expected visit %d to be %s, was %s", i, expectedVisits[i], visited[i])
+		}
+	}
+
+	expectedContent := []string{"inserted-before", "a", "b", "inserted-after", "c"}
+	if len(document.Content) != len(expectedContent) {
+		t.Fatalf("expected %d content blocks, was %d", len(expectedContent), len(document.Content))
+	}
+
+	for i := range expectedContent {
+		if document.Content[i].Type != expectedContent[i] {
+			t.Errorf("expected content %d to be %s, was %s", i, expectedContent[i], document.Content[i].Type)
+		}
+	}
+
+	if document.Content[1].Title != "changed" {
+		t.Error("expected changes to the visited block to be kept")
+	}
+}
+
+func TestWalkError(t *testing.T) {
+	document := loadDocument(t, "./testdata/text.json")
+	errVisit := errors.New("visit failed")
+
+	err := navigadoc.Walk(document, func(block *doc.Block, cursor *navigadoc.Cursor) (navigadoc.WalkAction, error) {
+		return navigadoc.WalkContinue, errVisit
+	})
+	if !errors.Is(err, errVisit) {
+		t.Errorf("expected visitor error, got %v", err)
+	}
+}